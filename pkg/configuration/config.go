@@ -28,12 +28,24 @@ import (
 
 type Config struct {
 	AdvertisedUrl                string `json:"advertised_url"`
+	Database                     string `json:"database"`
 	MongoUseRelSet               bool   `json:"mongo_use_rel_set"`
 	MongoUrl                     string `json:"mongo_url"`
 	MongoTable                   string `json:"mongo_table"`
 	MongoCollectionWatchedEntity string `json:"mongo_collection_watched_entity"`
+	MongoCollectionHistory       string `json:"mongo_collection_history"`
+	MongoCollectionTriggers      string `json:"mongo_collection_triggers"`
+	HistoryRetention             int64  `json:"history_retention"`
 	WatchInterval                string `json:"watch_interval"`
+	InstanceId                   string `json:"instance_id"`
+	FetchLeaseDuration           string `json:"fetch_lease_duration"`
 	BatchSize                    int64  `json:"batch_size"`
+	MaxConcurrentChecks          int    `json:"max_concurrent_checks"`
+	MaxFailureBackoff            string `json:"max_failure_backoff"`
+	MaxConsecutiveFailures       int    `json:"max_consecutive_failures"`
+	TriggerMaxAttempts           int    `json:"trigger_max_attempts"`
+	TriggerRetryBackoff          string `json:"trigger_retry_backoff"`
+	TriggerMaxRetryBackoff       string `json:"trigger_max_retry_backoff"`
 	WorkerParamPrefix            string `json:"worker_param_prefix"`
 	MinWatchInterval             string `json:"min_watch_interval"`
 	DefaultWatchInterval         string `json:"default_watch_interval"`
@@ -42,6 +54,21 @@ type Config struct {
 	AllowGenericWatchRequests    bool   `json:"allow_generic_watch_requests"`
 	UseExternalDnsForChecker     bool   `json:"use_external_dns_for_checker"`
 	ExternalDnsAddress           string `json:"external_dns_address"`
+	MaxResponseSize              int64  `json:"max_response_size"`
+	MaxRequestTimeout            string `json:"max_request_timeout"`
+	KafkaUrl                     string `json:"kafka_url"`
+	KafkaConsumerGroup           string `json:"kafka_consumer_group"`
+	KafkaResyncInterval          string `json:"kafka_resync_interval"`
+	SseReconnectBackoff          string `json:"sse_reconnect_backoff"`
+	SseMaxReconnectBackoff       string `json:"sse_max_reconnect_backoff"`
+	SseResyncInterval            string `json:"sse_resync_interval"`
+	CheckInitialJitter           string `json:"check_initial_jitter"` //max random delay of the first check of new watchers
+	CheckJitter                  string `json:"check_jitter"`         //max random delay added to every following check
+	CheckJitterSeed              int64  `json:"check_jitter_seed"`    //seed of the jitter, 0 uses a random seed
+
+	RateLimit          float64           `json:"rate_limit"`           //requests per second to one host by watch and trigger requests, 0 disables the limit
+	RateLimitBurst     int               `json:"rate_limit_burst"`     //requests to one host sent without delay after an idle period
+	RateLimitOverrides map[string]string `json:"rate_limit_overrides"` //requests per second by host name, replacing rate_limit; "0" disables the limit of the host
 
 	LogLevel string       `json:"log_level"`
 	logger   *slog.Logger `json:"-"`
@@ -70,3 +97,24 @@ func (this *Config) GetLogger() *slog.Logger {
 	}
 	return this.logger
 }
+
+const DefaultMaxRequestTimeout = time.Minute
+
+// GetMaxRequestTimeout returns the upper bound of the timeouts of watch and trigger requests, which is also the timeout of the http clients
+func (this *Config) GetMaxRequestTimeout() time.Duration {
+	return this.ParseDuration("max_request_timeout", this.MaxRequestTimeout, DefaultMaxRequestTimeout)
+}
+
+// ParseDuration returns the parsed duration config value; an empty or invalid value is replaced by defaultValue.
+// name is the json name of the config field, used to log invalid values
+func (this *Config) ParseDuration(name string, value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	result, err := time.ParseDuration(value)
+	if err != nil {
+		this.GetLogger().Warn("WARNING: invalid duration config --> use default", "name", name, "value", value, "default", defaultValue.String(), "error", err)
+		return defaultValue
+	}
+	return result
+}
@@ -50,7 +50,8 @@ func (config *Config) GetSaveHttpClient() *http.Client {
 	}
 
 	client := http.Client{
-		Timeout: 5 * time.Second,
+		//the checker and the trigger set shorter timeouts per request
+		Timeout: config.GetMaxRequestTimeout(),
 		Transport: &http.Transport{
 			Proxy:                 http.ProxyFromEnvironment,
 			DialContext:           dialContext,
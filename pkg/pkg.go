@@ -18,6 +18,7 @@ package pkg
 
 import (
 	"context"
+	"fmt"
 	lib "github.com/SENERGY-Platform/smart-service-module-worker-lib"
 	"github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/auth"
 	"github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/camunda"
@@ -28,7 +29,14 @@ import (
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/api"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/cleanup"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/mongo"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/ratelimit"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source/kafka"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source/sse"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/trigger"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/worker"
 	"sync"
@@ -36,20 +44,29 @@ import (
 
 func Start(ctx context.Context, wg *sync.WaitGroup, config configuration.Config, libConfig libconfiguration.Config) error {
 	handlerFactory := func(a *auth.Auth, smartServiceRepo *smartservicerepository.SmartServiceRepository) (camunda.Handler, error) {
-		db, err := mongo.New(config, ctx)
+		db, err := NewDatabase(ctx, config)
 		if err != nil {
 			return nil, err
 		}
-		c, err := checker.New(config, a)
+		//watch and trigger requests to the same host share its rate limit
+		limiter, err := ratelimit.New(config)
 		if err != nil {
 			return nil, err
 		}
-		t, err := trigger.New(config, a)
+		sources, err := NewSourcesWithLimiter(config, a, limiter)
+		if err != nil {
+			return nil, err
+		}
+		t, err := trigger.NewWithLimiter(config, a, limiter)
 		if err != nil {
 			return nil, err
 		}
 		cleanupChecker := cleanup.New(smartServiceRepo)
-		w := watcher.New(config, db, c, t, cleanupChecker)
+		w := watcher.New(config, db, sources, t, cleanupChecker)
+		err = sources.Start(ctx, wg, w)
+		if err != nil {
+			return nil, err
+		}
 		err = w.Start(ctx, wg)
 		if err != nil {
 			return nil, err
@@ -58,7 +75,64 @@ func Start(ctx context.Context, wg *sync.WaitGroup, config configuration.Config,
 		if err != nil {
 			return nil, err
 		}
-		return worker.New(config, libConfig, a, smartServiceRepo, w)
+		return worker.New(config, libConfig, a, smartServiceRepo, w, sources)
 	}
 	return lib.Start(ctx, wg, libConfig, handlerFactory)
 }
+
+// NewDatabase returns the db.Database implementation selected by config.Database ("mongo" or "memory")
+// an empty value defaults to mongo
+func NewDatabase(ctx context.Context, config configuration.Config) (db.Database, error) {
+	switch config.Database {
+	case "", "mongo":
+		m, err := mongo.New(config, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "memory":
+		m, err := memory.New(config)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("unknown database %#v", config.Database)
+	}
+}
+
+// NewSources returns the registry of all source types that may be watched
+func NewSources(config configuration.Config, a checker.Auth) (*source.Registry, error) {
+	limiter, err := ratelimit.New(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewSourcesWithLimiter(config, a, limiter)
+}
+
+// NewSourcesWithLimiter uses limiter for the requests of the http source
+func NewSourcesWithLimiter(config configuration.Config, a checker.Auth, limiter *ratelimit.Limiter) (*source.Registry, error) {
+	registry := source.NewRegistry()
+	c, err := checker.NewWithLimiter(config, a, limiter)
+	if err != nil {
+		return nil, err
+	}
+	err = registry.Register(model.SOURCE_TYPE_HTTP, c)
+	if err != nil {
+		return nil, err
+	}
+	if config.AllowGenericWatchRequests {
+		//like generic watch requests, sse endpoints are chosen by the user
+		err = registry.Register(sse.SOURCE_TYPE, sse.New(config, a))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.KafkaUrl != "" {
+		err = registry.Register(kafka.SOURCE_TYPE, kafka.New(config, kafka.NewReaderFactory(config)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/SENERGY-Platform/service-commons/pkg/accesslog"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/api/util"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
 	"github.com/julienschmidt/httprouter"
 )
 
@@ -37,6 +39,14 @@ var endpoints = []interface{}{} //list of objects with EndpointMethod
 
 type Controller interface {
 	DeleteWatcher(userId string, watcherId string) (err error)
+	GetHistory(userId string, watcherId string, limit int64) (result []model.HistoryEntry, err error)
+	ListWatchers(userId string, query db.QueryOptions) (result []model.WatchedEntity, err error)
+	GetWatcher(userId string, watcherId string) (result model.WatchedEntity, err error)
+	PauseWatcher(userId string, watcherId string) (err error)
+	ResumeWatcher(userId string, watcherId string) (err error)
+	CheckNow(userId string, watcherId string, dryRun bool) (result model.ManualCheckResult, err error)
+	ListDeadLetters(userId string) (result []model.PendingTrigger, err error)
+	ReplayDeadLetter(userId string, triggerId string) (err error)
 }
 
 func Start(ctx context.Context, config configuration.Config, ctrl Controller) (err error) {
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/ratelimit"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+func init() {
+	endpoints = append(endpoints, &MetricsEndpoints{})
+}
+
+type MetricsEndpoints struct{}
+
+// Metrics godoc
+// @Summary      metrics
+// @Description  returns the rate limit metrics: requests, throttled, rejected and wait_seconds by target host; only for admins, because the hosts of all users are listed
+// @Tags         metrics
+// @Security Bearer
+// @Produce      json
+// @Success      200
+// @Failure      401
+// @Failure      403
+// @Router       /metrics [get]
+func (this *MetricsEndpoints) Metrics(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/metrics", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.Parse(request.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if !token.IsAdmin() {
+			http.Error(writer, "only admins may read metrics", http.StatusForbidden)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, err = writer.Write([]byte(`{"rate_limit":` + ratelimit.Metrics().String() + "}"))
+		if err != nil {
+			config.GetLogger().Error("ERROR: unable to write response", "error", err)
+		}
+	})
+}
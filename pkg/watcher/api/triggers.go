@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	endpoints = append(endpoints, &TriggerEndpoints{})
+}
+
+type TriggerEndpoints struct{}
+
+// ListDeadLetters godoc
+// @Summary      list dead-lettered triggers
+// @Description  lists the triggers of the requesting user, that failed config.trigger_max_attempts times; header values are redacted
+// @Tags         trigger
+// @Security Bearer
+// @Produce      json
+// @Success      200 {array} model.PendingTrigger
+// @Failure      401
+// @Failure      500
+// @Router       /dead-letters [get]
+func (this *TriggerEndpoints) ListDeadLetters(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/dead-letters", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.Parse(request.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		result, err := ctrl.ListDeadLetters(token.GetUserId())
+		if err != nil {
+			http.Error(writer, err.Error(), getErrorCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("ERROR: unable to encode response", "error", err)
+		}
+	})
+}
+
+// ReplayDeadLetter godoc
+// @Summary      replay dead-lettered trigger
+// @Description  resets the attempts of a dead-lettered trigger of the requesting user, so that it is retried with the next watcher cycle
+// @Tags         trigger
+// @Security Bearer
+// @Param        id path string true "Trigger ID"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      404
+// @Failure      409
+// @Failure      500
+// @Router       /dead-letters/{id}/replay [post]
+func (this *TriggerEndpoints) ReplayDeadLetter(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/dead-letters/:id/replay", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.Parse(request.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		err = ctrl.ReplayDeadLetter(token.GetUserId(), id)
+		if err != nil {
+			http.Error(writer, err.Error(), getErrorCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+}
@@ -17,10 +17,19 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/auth"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
 	"github.com/julienschmidt/httprouter"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 func init() {
@@ -29,6 +38,83 @@ func init() {
 
 type WatcherEndpoints struct{}
 
+// List godoc
+// @Summary      list watchers
+// @Description  lists the watchers of the requesting user; header values of watch and trigger requests are redacted
+// @Tags         watcher
+// @Security Bearer
+// @Param        limit query integer false "default 100"
+// @Param        offset query integer false "default 0"
+// @Param        sort query string false "default id.asc; one of id, interval, hash_type, created_at, timestamp_of_next_check; optionally suffixed with .asc or .desc"
+// @Produce      json
+// @Success      200 {array} model.WatchedEntity
+// @Failure      400
+// @Failure      401
+// @Failure      500
+// @Router       /watcher [get]
+func (this *WatcherEndpoints) List(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/watcher", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.Parse(request.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		query, err := parseListQuery(request.URL.Query())
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err := ctrl.ListWatchers(token.GetUserId(), query)
+		if err != nil {
+			http.Error(writer, err.Error(), getErrorCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("ERROR: unable to encode response", "error", err)
+		}
+	})
+}
+
+// Get godoc
+// @Summary      get watcher
+// @Description  returns a watcher of the requesting user; header values of watch and trigger requests are redacted
+// @Tags         watcher
+// @Security Bearer
+// @Param        id path string true "Watcher ID"
+// @Produce      json
+// @Success      200 {object} model.WatchedEntity
+// @Failure      400
+// @Failure      401
+// @Failure      404
+// @Failure      500
+// @Router       /watcher/{id} [get]
+func (this *WatcherEndpoints) Get(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/watcher/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.Parse(request.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err := ctrl.GetWatcher(token.GetUserId(), id)
+		if err != nil {
+			http.Error(writer, err.Error(), getErrorCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("ERROR: unable to encode response", "error", err)
+		}
+	})
+}
+
 // Delete godoc
 // @Summary      removes a watcher
 // @Description  removes a watcher
@@ -58,3 +144,219 @@ func (this *WatcherEndpoints) Delete(config configuration.Config, router *httpro
 		writer.WriteHeader(http.StatusOK)
 	})
 }
+
+// Pause godoc
+// @Summary      pause watcher
+// @Description  pauses the checks of a watcher owned by the requesting user; the last known hash is kept
+// @Tags         watcher
+// @Security Bearer
+// @Param        id path string true "Watcher ID"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      404
+// @Failure      500
+// @Router       /watcher/{id}/pause [post]
+func (this *WatcherEndpoints) Pause(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/watcher/:id/pause", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.Parse(request.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		err = ctrl.PauseWatcher(token.GetUserId(), id)
+		if err != nil {
+			http.Error(writer, err.Error(), getErrorCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+}
+
+// Resume godoc
+// @Summary      resume watcher
+// @Description  resumes the checks of a paused watcher owned by the requesting user; a watcher disabled by consecutive check failures is re-enabled
+// @Tags         watcher
+// @Security Bearer
+// @Param        id path string true "Watcher ID"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      404
+// @Failure      500
+// @Router       /watcher/{id}/resume [post]
+func (this *WatcherEndpoints) Resume(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/watcher/:id/resume", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.Parse(request.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		err = ctrl.ResumeWatcher(token.GetUserId(), id)
+		if err != nil {
+			http.Error(writer, err.Error(), getErrorCode(err))
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	})
+}
+
+// Check godoc
+// @Summary      check watcher now
+// @Description  checks a watcher owned by the requesting user immediately; with dry_run=true the hash is neither stored nor is the trigger executed; a check that is not a dry run is rejected with 409 while another check of the watcher is running
+// @Tags         watcher
+// @Security Bearer
+// @Param        id path string true "Watcher ID"
+// @Param        dry_run query boolean false "default false"
+// @Produce      json
+// @Success      200 {object} model.ManualCheckResult
+// @Failure      400
+// @Failure      401
+// @Failure      404
+// @Failure      409
+// @Failure      500
+// @Router       /watcher/{id}/check [post]
+func (this *WatcherEndpoints) Check(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/watcher/:id/check", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.Parse(request.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		dryRun := false
+		if dryRunStr := request.URL.Query().Get("dry_run"); dryRunStr != "" {
+			dryRun, err = strconv.ParseBool(dryRunStr)
+			if err != nil {
+				http.Error(writer, "invalid dry_run: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		result, err := ctrl.CheckNow(token.GetUserId(), id, dryRun)
+		if err != nil {
+			http.Error(writer, err.Error(), getErrorCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("ERROR: unable to encode response", "error", err)
+		}
+	})
+}
+
+// History godoc
+// @Summary      check history of a watcher
+// @Description  returns the check history of a watcher owned by the requesting user, newest entry first
+// @Tags         watcher
+// @Security Bearer
+// @Param        id path string true "Watcher ID"
+// @Param        limit query integer false "max count of returned entries"
+// @Produce      json
+// @Success      200 {array} model.HistoryEntry
+// @Failure      400
+// @Failure      401
+// @Failure      404
+// @Failure      500
+// @Router       /watcher/{id}/history [get]
+func (this *WatcherEndpoints) History(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/watcher/:id/history", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.Parse(request.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		limit := int64(0)
+		if limitStr := request.URL.Query().Get("limit"); limitStr != "" {
+			limit, err = strconv.ParseInt(limitStr, 10, 64)
+			if err != nil {
+				http.Error(writer, "invalid limit: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		result, err := ctrl.GetHistory(token.GetUserId(), id, limit)
+		if err != nil {
+			http.Error(writer, err.Error(), getErrorCode(err))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("ERROR: unable to encode response", "error", err)
+		}
+	})
+}
+
+type ListQuery struct {
+	Limit  int64
+	Offset int64
+	Sort   string
+}
+
+func (this ListQuery) GetLimit() int64 {
+	return this.Limit
+}
+
+func (this ListQuery) GetOffset() int64 {
+	return this.Offset
+}
+
+func (this ListQuery) GetSort() string {
+	return this.Sort
+}
+
+func parseListQuery(values url.Values) (result ListQuery, err error) {
+	result = ListQuery{Limit: 100, Offset: 0, Sort: "id.asc"}
+	if limit := values.Get("limit"); limit != "" {
+		result.Limit, err = strconv.ParseInt(limit, 10, 64)
+		if err != nil {
+			return result, fmt.Errorf("invalid limit: %w", err)
+		}
+	}
+	if offset := values.Get("offset"); offset != "" {
+		result.Offset, err = strconv.ParseInt(offset, 10, 64)
+		if err != nil {
+			return result, fmt.Errorf("invalid offset: %w", err)
+		}
+		if result.Offset < 0 {
+			return result, errors.New("invalid offset: must not be negative")
+		}
+	}
+	if sort := values.Get("sort"); sort != "" {
+		field := strings.TrimSuffix(strings.TrimSuffix(sort, ".asc"), ".desc")
+		if !slices.Contains(db.SortFields, field) {
+			return result, fmt.Errorf("invalid sort field %#v", field)
+		}
+		result.Sort = sort
+	}
+	return result, nil
+}
+
+func getErrorCode(err error) int {
+	if errors.Is(err, db.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, watcher.ErrNotDeadLettered) || errors.Is(err, db.ErrLeased) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// canonicalJson encodes a value parsed by parseJson independent of key order, whitespace and number notation.
+// numbers are normalized textually by canonicalNumber (1, 1.0 and 1e0 are equal). if sortArrays is set, array elements are sorted
+// by their canonical encoding. the result is only meant for hashing and is not necessarily valid json.
+func canonicalJson(value interface{}, sortArrays bool) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := writeCanonicalJson(buf, value, sortArrays)
+	return buf.Bytes(), err
+}
+
+func writeCanonicalJson(buf *bytes.Buffer, value interface{}, sortArrays bool) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		if v {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case string:
+		temp, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(temp)
+	case json.Number:
+		number, err := canonicalNumber(v.String())
+		if err != nil {
+			return err
+		}
+		buf.WriteString(number)
+	case float64:
+		number, err := canonicalNumber(strconv.FormatFloat(v, 'g', -1, 64))
+		if err != nil {
+			return err
+		}
+		buf.WriteString(number)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			err := writeCanonicalJson(buf, key, sortArrays)
+			if err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			err = writeCanonicalJson(buf, v[key], sortArrays)
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		elements := make([][]byte, 0, len(v))
+		for _, element := range v {
+			temp, err := canonicalJson(element, sortArrays)
+			if err != nil {
+				return err
+			}
+			elements = append(elements, temp)
+		}
+		if sortArrays {
+			sort.Slice(elements, func(i, j int) bool {
+				return bytes.Compare(elements[i], elements[j]) < 0
+			})
+		}
+		buf.WriteByte('[')
+		buf.Write(bytes.Join(elements, []byte(",")))
+		buf.WriteByte(']')
+	default:
+		return fmt.Errorf("unexpected json value type %T", value)
+	}
+	return nil
+}
+
+// canonicalNumber encodes a json number as <significant digits>e<exponent> without leading or trailing zeros (e.g. 15e-1 for 1.50),
+// without arithmetic on the value, so that numbers like 1e1000000 are as cheap as their notation
+func canonicalNumber(number string) (string, error) {
+	sign := ""
+	text := number
+	if strings.HasPrefix(text, "-") {
+		sign = "-"
+		text = text[1:]
+	}
+	var exponent int64
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		var err error
+		exponent, err = strconv.ParseInt(strings.TrimPrefix(text[i+1:], "+"), 10, 32)
+		if err != nil {
+			return "", fmt.Errorf("invalid number exponent %v: %w", number, err)
+		}
+		text = text[:i]
+	}
+	integer, fraction, _ := strings.Cut(text, ".")
+	digits := integer + fraction
+	if integer == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return "", fmt.Errorf("invalid number %v", number)
+	}
+	significant := strings.TrimRight(strings.TrimLeft(digits, "0"), "0")
+	if significant == "" {
+		return "0", nil
+	}
+	trailingZeros := len(digits) - len(strings.TrimRight(digits, "0"))
+	exponent = exponent - int64(len(fraction)) + int64(trailingZeros)
+	return sign + significant + "e" + strconv.FormatInt(exponent, 10), nil
+}
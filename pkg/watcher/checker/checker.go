@@ -18,19 +18,35 @@ package checker
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/auth"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/ratelimit"
 	"io"
 	"net/http"
 	"time"
 )
 
+var ErrResponseTooLarge = errors.New("watched response exceeds max_response_size")
+
+const defaultMaxResponseSize = 10 << 20
+
+// maxErrorPayloadSize limits the response body in the errors of unaccepted status codes, which are stored as LastError and in the history
+const maxErrorPayloadSize = 1024
+
+// triggerInputResponse is the trigger input (watcher.TRIGGER_INPUT_RESPONSE) that needs the response payload after hashing
+const triggerInputResponse = "response"
+
 type Checker struct {
-	auth           Auth
-	client         *http.Client
-	isolatedClient *http.Client
+	auth            Auth
+	client          *http.Client
+	isolatedClient  *http.Client
+	maxResponseSize int64
+	maxTimeout      time.Duration
+	limiter         *ratelimit.Limiter
 }
 
 type Auth interface {
@@ -38,31 +54,184 @@ type Auth interface {
 }
 
 func New(config configuration.Config, auth Auth) (*Checker, error) {
+	limiter, err := ratelimit.New(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithLimiter(config, auth, limiter)
+}
+
+// NewWithLimiter uses limiter for all watch requests, e.g. to share the limits of a host with the trigger
+func NewWithLimiter(config configuration.Config, auth Auth, limiter *ratelimit.Limiter) (*Checker, error) {
+	maxResponseSize := config.MaxResponseSize
+	if maxResponseSize <= 0 {
+		maxResponseSize = defaultMaxResponseSize
+	}
+	maxTimeout := config.GetMaxRequestTimeout()
 	return &Checker{
-		auth: auth,
-		client: &http.Client{
-			Timeout: 5 * time.Second,
-		},
-		isolatedClient: config.GetSaveHttpClient(),
+		auth:            auth,
+		client:          &http.Client{Timeout: maxTimeout}, //shorter timeouts are set per request, see model.HttpRequest.GetTimeout
+		isolatedClient:  config.GetSaveHttpClient(),
+		maxResponseSize: maxResponseSize,
+		maxTimeout:      maxTimeout,
+		limiter:         limiter,
 	}, nil
 }
 
 func (this *Checker) Check(userId string, request model.HttpRequest, hashType string, lastHash string) (changed bool, newHash string, err error) {
-	payload, err := this.request(userId, request)
+	entity := model.WatchedEntity{}
+	entity.UserId = userId
+	entity.Watch = request
+	entity.HashType = hashType
+	entity.LastHash = lastHash
+	result, err := this.check(entity)
+	return result.Changed, result.Hash, err
+}
+
+func (this *Checker) CheckEntity(entity model.WatchedEntity) (result model.CheckResult, err error) {
+	return this.check(entity)
+}
+
+// Validate implements source.Source for model.SOURCE_TYPE_HTTP
+func (this *Checker) Validate(entity model.WatchedEntityInit) error {
+	timeout, err := entity.Watch.GetTimeout()
+	if err != nil {
+		return fmt.Errorf("invalid watch timeout: %w", err)
+	}
+	if timeout > this.maxTimeout {
+		return fmt.Errorf("invalid watch timeout: %v exceeds max_request_timeout %v", timeout, this.maxTimeout)
+	}
+	return nil
+}
+
+// check requests the watched response and hashes it. if the entity has a hash and ETag or Last-Modified,
+// the request is conditional and a 304 response is handled as unchanged;
+// a response used as trigger input is requested unconditionally, because a 304 response has no payload.
+func (this *Checker) check(entity model.WatchedEntity) (result model.CheckResult, err error) {
+	last := entity.WatchedEntityFetchInfo
+	if isPayloadInput(entity) {
+		last = model.WatchedEntityFetchInfo{}
+	}
+	resp, err := this.request(entity.UserId, entity.Watch, last)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode == http.StatusNotModified {
+		result.Hash = entity.LastHash
+		result.ETag = entity.ETag
+		result.LastModified = entity.LastModified
+		result.DeviceIds = entity.LastDeviceIds
+	} else {
+		if !entity.Watch.IsAccepted(resp.StatusCode) {
+			payload, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorPayloadSize))
+			return result, fmt.Errorf("unexpected watch response: %v, %v", resp.StatusCode, string(payload))
+		}
+		result.ETag = resp.Header.Get("ETag")
+		result.LastModified = resp.Header.Get("Last-Modified")
+		err = this.hashResponse(entity, resp, &result)
+		if err != nil {
+			return result, err
+		}
+		if entity.HashStatusCode {
+			result.Hash = withStatusCode(resp.StatusCode, result.Hash)
+		}
+	}
+	evaluate(entity, &result)
+	return result, nil
+}
+
+// CheckPayload handles a payload received by a push source like a watched http response
+func CheckPayload(entity model.WatchedEntity, payload []byte) (result model.CheckResult, err error) {
+	err = hashPayload(entity, payload, &result)
+	if err != nil {
+		return result, err
+	}
+	evaluate(entity, &result)
+	return result, nil
+}
+
+// evaluate compares the hash of the result to the last hash of the entity
+func evaluate(entity model.WatchedEntity, result *model.CheckResult) {
+	if entity.LastHash != result.Hash {
+		result.Changed = true
+	}
+	if entity.HashType == HASH_TYPE_DEVICEIDS {
+		result.DeviceDiff = diffDeviceIds(entity.LastDeviceIds, result.DeviceIds)
+	}
+	if entity.HashType == HASH_TYPE_PREDICATE {
+		contentHash := result.Hash
+		if entity.HashStatusCode {
+			contentHash = withoutStatusCode(contentHash)
+		}
+		value := contentHash == PREDICATE_TRUE
+		result.Predicate = &value
+		if value && entity.PredicateMode == PREDICATE_MODE_LEVEL {
+			//level-triggered: every true evaluation is handled as change
+			result.Changed = true
+		}
+	}
+}
+
+// hashResponse reads at most maxResponseSize bytes of the response body.
+// md5 and sha256 are hashed while reading, unless the payload is needed for ignored fields or as trigger input.
+func (this *Checker) hashResponse(entity model.WatchedEntity, resp *http.Response, result *model.CheckResult) (err error) {
+	if resp.ContentLength > this.maxResponseSize {
+		return fmt.Errorf("%w: content length %v > %v", ErrResponseTooLarge, resp.ContentLength, this.maxResponseSize)
+	}
+	body := &limitedReader{reader: resp.Body, remaining: this.maxResponseSize}
+	if isStreamable(entity.HashType) && len(entity.HashIgnoreFields) == 0 && !isPayloadInput(entity) {
+		result.Hash, err = streamHash(entity.HashType, body)
+		return err
+	}
+	payload, err := io.ReadAll(body)
 	if err != nil {
-		return false, "", err
+		return err
 	}
-	newHash, err = hash(hashType, payload)
+	return hashPayload(entity, payload, result)
+}
+
+func isPayloadInput(entity model.WatchedEntity) bool {
+	_, ok := entity.TriggerInputs[triggerInputResponse]
+	return ok
+}
+
+func hashPayload(entity model.WatchedEntity, payload []byte, result *model.CheckResult) (err error) {
+	result.Payload = payload
+	result.Hash, err = hashWithOptions(entity.HashType, entity.HashOptions, payload)
 	if err != nil {
-		return false, "", err
+		return err
 	}
-	if lastHash != newHash {
-		changed = true
+	if entity.HashType == HASH_TYPE_DEVICEIDS {
+		result.DeviceIds, err = findDeviceIdsWithOptions(entity.HashOptions, payload)
 	}
-	return changed, newHash, nil
+	return err
+}
+
+// limitedReader returns ErrResponseTooLarge as soon as more than remaining bytes are read
+type limitedReader struct {
+	reader    io.Reader
+	remaining int64
 }
 
-func (this *Checker) request(userId string, trigger model.HttpRequest) (payload []byte, err error) {
+func (this *limitedReader) Read(p []byte) (n int, err error) {
+	if this.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+	if int64(len(p)) > this.remaining+1 {
+		p = p[:this.remaining+1]
+	}
+	n, err = this.reader.Read(p)
+	this.remaining = this.remaining - int64(n)
+	if this.remaining < 0 {
+		return n, ErrResponseTooLarge
+	}
+	return n, err
+}
+
+// request sends the watch request; the caller has to close the body of the response
+func (this *Checker) request(userId string, trigger model.HttpRequest, last model.WatchedEntityFetchInfo) (resp *http.Response, err error) {
 	req, err := http.NewRequest(trigger.Method, trigger.Endpoint, bytes.NewReader(trigger.Body))
 	if err != nil {
 		return nil, err
@@ -70,6 +239,15 @@ func (this *Checker) request(userId string, trigger model.HttpRequest) (payload
 	for key, value := range trigger.Header {
 		req.Header[key] = value
 	}
+	if last.LastHash != "" {
+		//conditional requests are only useful if the hash of the cached response is known
+		if last.ETag != "" {
+			req.Header.Set("If-None-Match", last.ETag)
+		}
+		if last.LastModified != "" {
+			req.Header.Set("If-Modified-Since", last.LastModified)
+		}
+	}
 	if trigger.AddAuthToken {
 		token, err := this.auth.ExchangeUserToken(userId)
 		if err != nil {
@@ -77,19 +255,41 @@ func (this *Checker) request(userId string, trigger model.HttpRequest) (payload
 		}
 		req.Header.Set("Authorization", token.Jwt())
 	}
+	timeout, err := trigger.GetTimeout()
+	if err != nil {
+		return nil, err
+	}
+	timeout = min(timeout, this.maxTimeout)
 	var client *http.Client
 	if trigger.Isolated {
 		client = this.isolatedClient
 	} else {
 		client = this.client
 	}
-	resp, err := client.Do(req)
+	//the timeout includes the delay of throttled requests, which keeps checks within their fetch lease
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	err = this.limiter.Wait(ctx, trigger.Endpoint)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
-	payload, _ = io.ReadAll(resp.Body)
-	if resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("unexpected trigger response: %v, %v", resp.StatusCode, string(payload))
+	resp, err = client.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
 	}
-	return payload, nil
+	//the timeout includes reading the body
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the request context when the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (this *cancelOnClose) Close() error {
+	defer this.cancel()
+	return this.ReadCloser.Close()
 }
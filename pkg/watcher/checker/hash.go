@@ -17,19 +17,52 @@
 package checker
 
 import (
+	"bytes"
 	"crypto/md5"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
+	gohash "hash"
+	"io"
 	"regexp"
 	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/jsonpath"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/predicate"
 )
 
 const HASH_TYPE_MD5 = "md5"
 const HASH_TYPE_SHA256 = "sha256"
 const HASH_TYPE_DEVICEIDS = "deviceids"
+const HASH_TYPE_JSONPATH = "jsonpath"
+const HASH_TYPE_JSON_CANONICAL = "json_canonical"
+const HASH_TYPE_PREDICATE = "predicate"
+
+// PREDICATE_MODE_EDGE triggers when the predicate changes from false to true, PREDICATE_MODE_LEVEL on every true evaluation.
+// in both modes, a true predicate on the first check of an entity is triggered, because the state before is unknown and counts as false.
+const PREDICATE_MODE_EDGE = "edge"
+const PREDICATE_MODE_LEVEL = "level"
+
+// hashes of the predicate hash type
+const PREDICATE_TRUE = "true"
+const PREDICATE_FALSE = "false"
 
 func hash(hashType string, payload []byte) (string, error) {
+	return hashWithOptions(hashType, model.HashOptions{}, payload)
+}
+
+func hashWithOptions(hashType string, options model.HashOptions, payload []byte) (string, error) {
+	if len(options.HashIgnoreFields) > 0 {
+		var err error
+		payload, err = withoutIgnoredFields(options.HashIgnoreFields, payload)
+		if err != nil {
+			return "", err
+		}
+	}
 	switch hashType {
 	case HASH_TYPE_MD5:
 		return fmt.Sprintf("%x", md5.Sum(payload)), nil
@@ -37,11 +70,120 @@ func hash(hashType string, payload []byte) (string, error) {
 		return fmt.Sprintf("%x", sha256.Sum256(payload)), nil
 	case HASH_TYPE_DEVICEIDS:
 		return deviceIdsHash(payload)
+	case HASH_TYPE_JSONPATH:
+		return jsonPathHash(options, payload)
+	case HASH_TYPE_JSON_CANONICAL:
+		return jsonCanonicalHash(options, payload)
+	case HASH_TYPE_PREDICATE:
+		return predicateHash(options, payload)
 	default:
 		return fmt.Sprintf("%x", md5.Sum(payload)), nil
 	}
 }
 
+func isStreamable(hashType string) bool {
+	return hashType == HASH_TYPE_MD5 || hashType == HASH_TYPE_SHA256
+}
+
+// streamHash hashes the reader content for hash types accepted by isStreamable, without buffering it
+func streamHash(hashType string, reader io.Reader) (string, error) {
+	var hasher gohash.Hash
+	switch hashType {
+	case HASH_TYPE_MD5:
+		hasher = md5.New()
+	case HASH_TYPE_SHA256:
+		hasher = sha256.New()
+	default:
+		return "", fmt.Errorf("hash type %v can not be streamed", hashType)
+	}
+	_, err := io.Copy(hasher, reader)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hasher.Sum(nil)), nil
+}
+
+// jsonPathHash hashes the canonical json of the values selected by options.HashPaths
+func jsonPathHash(options model.HashOptions, payload []byte) (string, error) {
+	paths := options.HashPaths
+	if len(paths) == 0 {
+		return "", errors.New("hash type jsonpath requires at least one hash path")
+	}
+	doc, err := parseJson(payload)
+	if err != nil {
+		return "", err
+	}
+	selected := make([]interface{}, 0, len(paths))
+	for _, path := range paths {
+		values, err := jsonpath.Select(path, doc)
+		if err != nil {
+			return "", err
+		}
+		selected = append(selected, values)
+	}
+	temp, err := canonicalJson(selected, options.HashSortArrays)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", md5.Sum(temp)), nil
+}
+
+// jsonCanonicalHash hashes the payload independent of key order, whitespace and number notation
+func jsonCanonicalHash(options model.HashOptions, payload []byte) (string, error) {
+	doc, err := parseJson(payload)
+	if err != nil {
+		return "", err
+	}
+	temp, err := canonicalJson(doc, options.HashSortArrays)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", md5.Sum(temp)), nil
+}
+
+// withoutIgnoredFields parses the payload as json and returns its compact encoding without the ignored fields.
+// the result is used as payload for every hash type, so md5 and sha256 also hash a normalized document.
+func withoutIgnoredFields(fields []string, payload []byte) ([]byte, error) {
+	doc, err := parseJson(payload)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = removeIgnoredFields(doc, fields)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// predicateHash returns PREDICATE_TRUE or PREDICATE_FALSE as result of options.Predicate for the payload
+func predicateHash(options model.HashOptions, payload []byte) (string, error) {
+	if options.Predicate == "" {
+		return "", errors.New("hash type predicate requires a predicate")
+	}
+	expression, err := predicate.Parse(options.Predicate)
+	if err != nil {
+		return "", err
+	}
+	doc, err := parseJson(payload)
+	if err != nil {
+		return "", err
+	}
+	if expression.Evaluate(doc) {
+		return PREDICATE_TRUE, nil
+	}
+	return PREDICATE_FALSE, nil
+}
+
+func parseJson(payload []byte) (result interface{}, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	err = decoder.Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse watched response as json: %w", err)
+	}
+	return result, nil
+}
+
 func deviceIdsHash(payload []byte) (string, error) {
 	deviceIds, err := findDeviceIds(payload)
 	if err != nil {
@@ -54,9 +196,60 @@ func deviceIdsHash(payload []byte) (string, error) {
 	return fmt.Sprintf("%x", md5.Sum(temp)), nil
 }
 
+// findDeviceIdsWithOptions returns the device ids used by the deviceids hash type
+func findDeviceIdsWithOptions(options model.HashOptions, payload []byte) (ids []string, err error) {
+	if len(options.HashIgnoreFields) > 0 {
+		payload, err = withoutIgnoredFields(options.HashIgnoreFields, payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return findDeviceIds(payload)
+}
+
+// diffDeviceIds returns the sorted and distinct ids that are only in current (added) or only in last (removed)
+func diffDeviceIds(last []string, current []string) *model.DeviceDiff {
+	lastSet := map[string]bool{}
+	for _, id := range last {
+		lastSet[id] = true
+	}
+	currentSet := map[string]bool{}
+	for _, id := range current {
+		currentSet[id] = true
+	}
+	result := &model.DeviceDiff{Added: []string{}, Removed: []string{}}
+	for id := range currentSet {
+		if !lastSet[id] {
+			result.Added = append(result.Added, id)
+		}
+	}
+	for id := range lastSet {
+		if !currentSet[id] {
+			result.Removed = append(result.Removed, id)
+		}
+	}
+	sort.Strings(result.Added)
+	sort.Strings(result.Removed)
+	return result
+}
+
 func findDeviceIds(payload []byte) (ids []string, err error) {
 	exp := "urn:infai:ses:device:[0-9a-x-]{36}"
 	ids = regexp.MustCompile(exp).FindAllString(string(payload), -1)
 	sort.Strings(ids)
 	return ids, nil
 }
+
+// withStatusCode prefixes the hash with the status code of the response, see model.HashOptions.HashStatusCode
+func withStatusCode(statusCode int, hash string) string {
+	return strconv.Itoa(statusCode) + ":" + hash
+}
+
+// withoutStatusCode returns the hash of the response content of a hash created by withStatusCode
+func withoutStatusCode(hash string) string {
+	_, contentHash, found := strings.Cut(hash, ":")
+	if !found {
+		return hash
+	}
+	return contentHash
+}
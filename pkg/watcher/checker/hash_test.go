@@ -20,6 +20,9 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
 )
 
 var testPayloadWithDevices = `[{"annotations":{"connected":true},"attributes":[{"key":"optimise-mobile-app/favorite","origin":"optimise-mobile-app","value":"true"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"for zwavejs2mqtt","device_class_id":"urn:infai:ses:device-class:f00e3915-9f09-4aa4-9aaf-1177459c4312","id":"urn:infai:ses:device-type:877ede3a-26b7-406d-93a9-c40c0dbfed75","name":"ZMNHXDx 3-phase Smart Meter (Qubino) (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:6c310c8a-3790-49ac-900a-4248f4465af1","urn:infai:ses:service:7a2f14b1-4542-4499-bbd6-ec20e1c4ffe7","urn:infai:ses:service:e648f6d0-1020-4d15-b939-a4a4115a8c57","urn:infai:ses:service:0e19359e-76ad-4d53-ae33-966c0246311a","urn:infai:ses:service:3911175c-8845-46b8-bf5a-83efa6cf9d3f","urn:infai:ses:service:17051d9b-df19-414f-9b04-db77fbf07e84","urn:infai:ses:service:f8299078-0124-40f2-af52-9c74bd21ded9","urn:infai:ses:service:147e0ff7-90ef-409f-8354-7823c4b9dcbf","urn:infai:ses:service:e2f1f7d9-547c-4954-8dac-b60b86f1ced9","urn:infai:ses:service:ef5a1bf3-0114-4b03-a759-445e05536785","urn:infai:ses:service:cfc6a85f-01ab-4017-9d57-92fb050bc0fa","urn:infai:ses:service:f0ba1148-e164-41d2-a3f6-9b5f9939007c","urn:infai:ses:service:d3524ff6-9c0d-49e1-b5ea-2a891c725f7b","urn:infai:ses:service:ea6a984d-c7ea-43a0-88ad-04ec68178eaf","urn:infai:ses:service:9ba92218-37d8-4c80-ad3d-bb3eb5c8457d","urn:infai:ses:service:4b389e54-c008-486f-8ecd-55881a124977"],"service_groups":[{"description":"","key":"c3b8fc33-1899-4595-a6fc-eb85ff24a0de","name":"Total"},{"description":"","key":"fb9dd393-589c-4f2e-ae96-584f07e71330","name":"Phase 1"},{"description":"","key":"2340810e-044f-47f8-8db2-cc3d290de98b","name":"Phase 2"},{"description":"","key":"9c36b3d0-bf20-46d7-80b6-f05797c500f0","name":"Phase 3"}],"shared":false},"device_type_id":"urn:infai:ses:device-type:877ede3a-26b7-406d-93a9-c40c0dbfed75","display_name":"3-Phasen-Smart-Meter","id":"urn:infai:ses:device:449bed81-df72-4754-a9ba-df2873046734","local_id":"C01012117002988:7","log_state":true,"name":"3-Phasen-Smart-Meter","nickname":null,"permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"for zwavejs2mqtt","device_class_id":"urn:infai:ses:device-class:f00e3915-9f09-4aa4-9aaf-1177459c4312","id":"urn:infai:ses:device-type:877ede3a-26b7-406d-93a9-c40c0dbfed75","name":"ZMNHXDx 3-phase Smart Meter (Qubino) (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:6c310c8a-3790-49ac-900a-4248f4465af1","urn:infai:ses:service:7a2f14b1-4542-4499-bbd6-ec20e1c4ffe7","urn:infai:ses:service:e648f6d0-1020-4d15-b939-a4a4115a8c57","urn:infai:ses:service:0e19359e-76ad-4d53-ae33-966c0246311a","urn:infai:ses:service:3911175c-8845-46b8-bf5a-83efa6cf9d3f","urn:infai:ses:service:17051d9b-df19-414f-9b04-db77fbf07e84","urn:infai:ses:service:f8299078-0124-40f2-af52-9c74bd21ded9","urn:infai:ses:service:147e0ff7-90ef-409f-8354-7823c4b9dcbf","urn:infai:ses:service:e2f1f7d9-547c-4954-8dac-b60b86f1ced9","urn:infai:ses:service:ef5a1bf3-0114-4b03-a759-445e05536785","urn:infai:ses:service:cfc6a85f-01ab-4017-9d57-92fb050bc0fa","urn:infai:ses:service:f0ba1148-e164-41d2-a3f6-9b5f9939007c","urn:infai:ses:service:d3524ff6-9c0d-49e1-b5ea-2a891c725f7b","urn:infai:ses:service:ea6a984d-c7ea-43a0-88ad-04ec68178eaf","urn:infai:ses:service:9ba92218-37d8-4c80-ad3d-bb3eb5c8457d","urn:infai:ses:service:4b389e54-c008-486f-8ecd-55881a124977"],"service_groups":[{"description":"","key":"c3b8fc33-1899-4595-a6fc-eb85ff24a0de","name":"Total"},{"description":"","key":"fb9dd393-589c-4f2e-ae96-584f07e71330","name":"Phase 1"},{"description":"","key":"2340810e-044f-47f8-8db2-cc3d290de98b","name":"Phase 2"},{"description":"","key":"9c36b3d0-bf20-46d7-80b6-f05797c500f0","name":"Phase 3"}],"shared":false},"device_type_id":"urn:infai:ses:device-type:877ede3a-26b7-406d-93a9-c40c0dbfed75","display_name":"3_Phase_Smart_Meter_-_Flur_55","id":"urn:infai:ses:device:6032c113-5444-40a4-a3ab-1ef839a95f12","local_id":"5bbfd68e817a40129138ed8dc8547649:55","log_state":false,"name":"3_Phase_Smart_Meter_-_Flur_55","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"6219dc42-b8d0-4b42-851a-1c5956149944","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"for zwavejs2mqtt","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:10e11d97-3501-4103-a587-f9e75a493598","name":"ABUS SHLM10010 Z-Wave LED Lampe (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:331b0309-9317-4471-9403-c478ac743482","urn:infai:ses:service:c55cc23d-02f5-4713-8b6e-32333abb8184","urn:infai:ses:service:61e67f22-5927-4ad2-9fac-f2803d7fa8ce","urn:infai:ses:service:a27d1c3b-b992-47f8-a6b3-3e48bc1f37f4","urn:infai:ses:service:1e81373a-109b-4244-b6e3-12e648ae29ef"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:10e11d97-3501-4103-a587-f9e75a493598","display_name":"Abus_Light_Bulb_-_Schreibtisch_34","id":"urn:infai:ses:device:7b70ee31-bb91-4cc5-88ab-51a4b6497ba4","local_id":"d41b936021e541eddf0ab28307306cc7:34","log_state":false,"name":"Abus_Light_Bulb_-_Schreibtisch_34","nickname":null,"permission_holders":{"admin_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"execute_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"read_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"write_users":["6219dc42-b8d0-4b42-851a-1c5956149944"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"attributes":null,"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","device_type":{"attributes":null,"creator":"6219dc42-b8d0-4b42-851a-1c5956149944","description":"","device_class_id":"urn:infai:ses:device-class:ff64280a-58e6-4cf9-9a44-e70d3831a79d","id":"urn:infai:ses:device-type:39d1e71a-a5d2-4471-b251-466f60c7d398","name":"Aeotec Multisensor Gen 6 (deprecated)","permission_holders":{"admin_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"execute_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"read_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"write_users":["6219dc42-b8d0-4b42-851a-1c5956149944"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:76df88c6-4144-4eae-8a8b-d83be55d0f68","urn:infai:ses:service:19e2a58f-b50c-4def-b5d2-8d9c782bb1e0","urn:infai:ses:service:64ad04a3-de5e-4139-8e70-1946d27a35b7","urn:infai:ses:service:f0dd0dea-a5fd-477c-b4c6-5e46d59d5d41","urn:infai:ses:service:2e286ba3-7e62-438f-86dd-261bbdb0b917","urn:infai:ses:service:824f709b-dac7-47b6-8096-489cf24d5b98","urn:infai:ses:service:5aa3a36e-ca21-4a82-80a6-13ca4b33b81b"],"service_groups":null,"shared":true},"device_type_id":"urn:infai:ses:device-type:39d1e71a-a5d2-4471-b251-466f60c7d398","display_name":"Aeotec Multisensor Gen 6 (#46)","id":"urn:infai:ses:device:db109573-79e7-4735-9356-86524ade0458","local_id":"e3a7a0a7f35c9c9615839eca59db5b7d-46","name":"Aeotec Multisensor Gen 6 (#46)","nickname":null,"permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":false},{"annotations":{"connected":true},"attributes":[{"key":"AirQEventPrefix","origin":"web-ui","value":"airq"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","device_type":{"attributes":[{"key":"senergy/local-mqtt","origin":"web-ui","value":"true"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","description":"","device_class_id":"urn:infai:ses:device-class:8bd38ea2-1835-4a1e-ac02-6b3169513fd3","id":"urn:infai:ses:device-type:76f13387-fce8-4afa-85d3-9cb775be447c","name":"AirQ Science Edition","permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:4877987e-83a3-4872-bfcc-22bfd0fbfd06"],"service_groups":[],"shared":true},"device_type_id":"urn:infai:ses:device-type:76f13387-fce8-4afa-85d3-9cb775be447c","display_name":"Air-Q-Küche","id":"urn:infai:ses:device:e01174d5-1790-4d11-9df0-7506e66b2d53","local_id":"2","log_state":true,"name":"Air-Q-Küche","nickname":null,"permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"AirQEventPrefix","origin":"web-ui","value":"airq"},{"key":"optimise-mobile-app/favorite","origin":"optimise-mobile-app","value":"true"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","device_type":{"attributes":[{"key":"senergy/local-mqtt","origin":"web-ui","value":"true"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","description":"","device_class_id":"urn:infai:ses:device-class:8bd38ea2-1835-4a1e-ac02-6b3169513fd3","id":"urn:infai:ses:device-type:76f13387-fce8-4afa-85d3-9cb775be447c","name":"AirQ Science Edition","permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:4877987e-83a3-4872-bfcc-22bfd0fbfd06"],"service_groups":[],"shared":true},"device_type_id":"urn:infai:ses:device-type:76f13387-fce8-4afa-85d3-9cb775be447c","display_name":"Air-Q-Office","id":"urn:infai:ses:device:93c4c45c-e445-41e7-8bad-c82a3dea1b7e","local_id":"1","log_state":true,"name":"Air-Q-Office","nickname":null,"permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Measure air particles","device_class_id":"urn:infai:ses:device-class:8bd38ea2-1835-4a1e-ac02-6b3169513fd3","id":"urn:infai:ses:device-type:a8cbd322-9d8c-4f4c-afec-ae4b7986b6ed","name":"Blebox-Air-Sensor","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:1d20a68b-7136-456c-ace5-c3adb66866bf","urn:infai:ses:service:422fd899-a2cc-4e43-8d81-4e330a7ca8ab","urn:infai:ses:service:68a6aaeb-c0bc-46ce-aba4-8cf65a2dd4e5"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:a8cbd322-9d8c-4f4c-afec-ae4b7986b6ed","display_name":"airSensor","id":"urn:infai:ses:device:b2de769f-85c2-4f42-9314-7454adca7922","local_id":"x2gHd2fwdxjUR_lmee3bLw-5ecf7fb0bf6d","log_state":false,"name":"airSensor","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":[{"key":"firmware","origin":"local-mgw","value":"V1.04.12"},{"key":"manufacturer","origin":"local-mgw","value":"OSRAM"},{"key":"model","origin":"local-mgw","value":"Plug 01"}],"creator":"6219dc42-b8d0-4b42-851a-1c5956149944","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:79de1bd9-b933-412d-b98e-4cfe19aa3250","id":"urn:infai:ses:device-type:ddc87658-332e-4839-ae5d-b9896f4832d9","name":"Philips On Off Plug In Unit","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:7f7fca4b-8812-487b-956f-351c5cda0098","urn:infai:ses:service:63d203a1-8daf-4d85-b496-4c0414c5047f","urn:infai:ses:service:41e620d3-6910-41dd-bd34-dce9f460e975"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:ddc87658-332e-4839-ae5d-b9896f4832d9","display_name":"Alexa On/Off plug","id":"urn:infai:ses:device:c2871b25-5296-4f28-8fb6-f355bfb8ed5e","local_id":"fQws8YCq3ATRyWzEKpVpdg-7c:b0:3e:aa:00:b1:a8:3c-03","log_state":false,"name":"Alexa On/Off plug","nickname":null,"permission_holders":{"admin_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"execute_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"read_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"write_users":["6219dc42-b8d0-4b42-851a-1c5956149944"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"manufacturer","origin":"local-mgw","value":"Signify Netherlands B.V."},{"key":"model","origin":"local-mgw","value":"LCX002"},{"key":"firmware","origin":"local-mgw","value":"1.97.3"}],"creator":"93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Extended Color Light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","name":"Philips Extended Color Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:ba338bca-15d5-4041-a2a9-6960b270b113","urn:infai:ses:service:510941da-4f18-403f-9c28-8ba686439ec6","urn:infai:ses:service:86d8a826-f875-41c9-97ea-632e6b735609","urn:infai:ses:service:3118d662-77d4-436e-8274-8430b3bce54f","urn:infai:ses:service:88d87961-92d6-40c5-9a93-5da7519c7057","urn:infai:ses:service:dc9c8ccc-6bf0-43a3-8173-3f3987ac7040","urn:infai:ses:service:1ddd91b4-b86f-4042-9275-3b88a7cd17a7","urn:infai:ses:service:d150a92a-6b8d-41a5-ba1c-c71847a3b722","urn:infai:ses:service:da5e4f27-040b-43e0-a8d4-d5c8352803ea"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","display_name":"Ambient Light","id":"urn:infai:ses:device:b1666e73-b3a4-4042-b92f-5e73b2856706","local_id":"bce47779-d947-4813-8ae4-78667c76546b-00:17:88:01:0b:4d:dc:cc-0b","log_state":true,"name":"Ambient Light","nickname":null,"permission_holders":{"admin_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"execute_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"read_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"write_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"firmware","origin":"","value":"1.88.1"},{"key":"firmware","origin":"local-mgw","value":"1.88.1"},{"key":"manufacturer","origin":"","value":"Signify Netherlands B.V."},{"key":"manufacturer","origin":"local-mgw","value":"Signify Netherlands B.V."},{"key":"model","origin":"","value":"LTC015"},{"key":"model","origin":"local-mgw","value":"LTC015"}],"creator":"93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Color temperature light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","name":"Philips Color Temperature Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:d151ced8-1b76-4220-845b-8c5bbdcc7538","urn:infai:ses:service:0bd4b3e8-a7c2-4e90-b0b9-982c7591f454","urn:infai:ses:service:f22a9a47-6c56-461f-b594-db7fc0047b17","urn:infai:ses:service:5a182b68-7818-4bef-ad16-c01f4f0abafc","urn:infai:ses:service:0b7d13ef-43f0-4ae3-a38f-be1b05eeb944","urn:infai:ses:service:c1039edc-d711-4bf7-97fd-08ac8023e7ab","urn:infai:ses:service:eb6096c3-876c-4816-ba58-ec18c227aa81"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","display_name":"Ankleide","id":"urn:infai:ses:device:e889c9a7-4dd8-4eba-be0d-5e2183c8086c","local_id":"bce47779-d947-4813-8ae4-78667c76546b-00:17:88:01:04:50:11:71-0b","log_state":true,"name":"Ankleide","nickname":null,"permission_holders":{"admin_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"execute_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"read_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"write_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":[{"key":"firmware","origin":"local-mgw","value":"2.00.03"},{"key":"manufacturer","origin":"local-mgw","value":"innr"},{"key":"model","origin":"local-mgw","value":"RS 230 C"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Extended Color Light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","name":"Philips Extended Color Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:ba338bca-15d5-4041-a2a9-6960b270b113","urn:infai:ses:service:510941da-4f18-403f-9c28-8ba686439ec6","urn:infai:ses:service:86d8a826-f875-41c9-97ea-632e6b735609","urn:infai:ses:service:3118d662-77d4-436e-8274-8430b3bce54f","urn:infai:ses:service:88d87961-92d6-40c5-9a93-5da7519c7057","urn:infai:ses:service:dc9c8ccc-6bf0-43a3-8173-3f3987ac7040","urn:infai:ses:service:1ddd91b4-b86f-4042-9275-3b88a7cd17a7","urn:infai:ses:service:d150a92a-6b8d-41a5-ba1c-c71847a3b722","urn:infai:ses:service:da5e4f27-040b-43e0-a8d4-d5c8352803ea"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","display_name":"Bad Spot #1","id":"urn:infai:ses:device:68b016e6-af73-447e-b706-603e03d38a76","local_id":"bce47779-d947-4813-8ae4-78667c76546b-70:ac:08:ff:fe:a2:25:4e-01","log_state":false,"name":"Bad Spot #1","nickname":null,"permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":[{"key":"firmware","origin":"local-mgw","value":"2.00.03"},{"key":"manufacturer","origin":"local-mgw","value":"innr"},{"key":"model","origin":"local-mgw","value":"RS 230 C"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Extended Color Light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","name":"Philips Extended Color Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:ba338bca-15d5-4041-a2a9-6960b270b113","urn:infai:ses:service:510941da-4f18-403f-9c28-8ba686439ec6","urn:infai:ses:service:86d8a826-f875-41c9-97ea-632e6b735609","urn:infai:ses:service:3118d662-77d4-436e-8274-8430b3bce54f","urn:infai:ses:service:88d87961-92d6-40c5-9a93-5da7519c7057","urn:infai:ses:service:dc9c8ccc-6bf0-43a3-8173-3f3987ac7040","urn:infai:ses:service:1ddd91b4-b86f-4042-9275-3b88a7cd17a7","urn:infai:ses:service:d150a92a-6b8d-41a5-ba1c-c71847a3b722","urn:infai:ses:service:da5e4f27-040b-43e0-a8d4-d5c8352803ea"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","display_name":"Bad Spot #2","id":"urn:infai:ses:device:c01ae326-1602-46bc-89b3-f7f3c5c2a0fc","local_id":"bce47779-d947-4813-8ae4-78667c76546b-70:ac:08:ff:fe:a0:9d:77-01","log_state":false,"name":"Bad Spot #2","nickname":null,"permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":[{"key":"firmware","origin":"local-mgw","value":"2.00.03"},{"key":"manufacturer","origin":"local-mgw","value":"innr"},{"key":"model","origin":"local-mgw","value":"RS 230 C"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Extended Color Light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","name":"Philips Extended Color Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:ba338bca-15d5-4041-a2a9-6960b270b113","urn:infai:ses:service:510941da-4f18-403f-9c28-8ba686439ec6","urn:infai:ses:service:86d8a826-f875-41c9-97ea-632e6b735609","urn:infai:ses:service:3118d662-77d4-436e-8274-8430b3bce54f","urn:infai:ses:service:88d87961-92d6-40c5-9a93-5da7519c7057","urn:infai:ses:service:dc9c8ccc-6bf0-43a3-8173-3f3987ac7040","urn:infai:ses:service:1ddd91b4-b86f-4042-9275-3b88a7cd17a7","urn:infai:ses:service:d150a92a-6b8d-41a5-ba1c-c71847a3b722","urn:infai:ses:service:da5e4f27-040b-43e0-a8d4-d5c8352803ea"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","display_name":"Bad Spot #3","id":"urn:infai:ses:device:0234eacd-6d5c-4142-8efd-106b6d4c93fb","local_id":"bce47779-d947-4813-8ae4-78667c76546b-70:ac:08:ff:fe:a2:0d:08-01","log_state":false,"name":"Bad Spot #3","nickname":null,"permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":[{"key":"firmware","origin":"local-mgw","value":"2.00.03"},{"key":"manufacturer","origin":"local-mgw","value":"innr"},{"key":"model","origin":"local-mgw","value":"RS 230 C"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Extended Color Light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","name":"Philips Extended Color Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:ba338bca-15d5-4041-a2a9-6960b270b113","urn:infai:ses:service:510941da-4f18-403f-9c28-8ba686439ec6","urn:infai:ses:service:86d8a826-f875-41c9-97ea-632e6b735609","urn:infai:ses:service:3118d662-77d4-436e-8274-8430b3bce54f","urn:infai:ses:service:88d87961-92d6-40c5-9a93-5da7519c7057","urn:infai:ses:service:dc9c8ccc-6bf0-43a3-8173-3f3987ac7040","urn:infai:ses:service:1ddd91b4-b86f-4042-9275-3b88a7cd17a7","urn:infai:ses:service:d150a92a-6b8d-41a5-ba1c-c71847a3b722","urn:infai:ses:service:da5e4f27-040b-43e0-a8d4-d5c8352803ea"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","display_name":"Bad Spot #4","id":"urn:infai:ses:device:8abdb6f9-d0c9-4da9-9414-346448a88017","local_id":"bce47779-d947-4813-8ae4-78667c76546b-70:ac:08:ff:fe:a2:07:2d-01","log_state":false,"name":"Bad Spot #4","nickname":null,"permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"TZEventPrefix","origin":"web-ui","value":"esp-tz"},{"key":"optimise-mobile-app/favorite","origin":"optimise-mobile-app","value":"true"},{"key":"shared/nickname","origin":"shared","value":"Bewässerungssteuerung"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","device_type":{"attributes":[{"key":"senergy/local-mqtt","origin":"web-ui","value":"true"}],"creator":"61083c0c-3204-4ec1-8143-97fea19dab8e","description":"","device_class_id":"urn:infai:ses:device-class:5abacb75-3895-41b7-ac68-00542980b60c","id":"urn:infai:ses:device-type:d51f6f4d-9f9b-46c2-b1f1-608cb53b8fce","name":"TZ-Irrigation-Control","permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ecd68fa-3a6c-45de-916b-f0967545c44d","urn:infai:ses:service:838121c1-1a1d-4c8f-81b8-6c73eac49f9c","urn:infai:ses:service:55f01ffc-c0d8-4306-815f-eacfc95256d0","urn:infai:ses:service:929d5f44-9418-4553-b6ef-f77e9bc32af1","urn:infai:ses:service:81fab796-dd27-4884-80c3-b16bde657753"],"service_groups":[],"shared":true},"device_type_id":"urn:infai:ses:device-type:d51f6f4d-9f9b-46c2-b1f1-608cb53b8fce","display_name":"Bewässerungssteuerung","id":"urn:infai:ses:device:257869bd-e9bb-4eca-a244-c848337b8173","local_id":"esp-tz","log_state":true,"name":"","nickname":"Bewässerungssteuerung","permission_holders":{"admin_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"execute_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"read_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"],"write_users":["61083c0c-3204-4ec1-8143-97fea19dab8e"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"6219dc42-b8d0-4b42-851a-1c5956149944","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Measure air particles","device_class_id":"urn:infai:ses:device-class:8bd38ea2-1835-4a1e-ac02-6b3169513fd3","id":"urn:infai:ses:device-type:a8cbd322-9d8c-4f4c-afec-ae4b7986b6ed","name":"Blebox-Air-Sensor","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:1d20a68b-7136-456c-ace5-c3adb66866bf","urn:infai:ses:service:422fd899-a2cc-4e43-8d81-4e330a7ca8ab","urn:infai:ses:service:68a6aaeb-c0bc-46ce-aba4-8cf65a2dd4e5"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:a8cbd322-9d8c-4f4c-afec-ae4b7986b6ed","display_name":"BleBox Labor","id":"urn:infai:ses:device:d42ed4a5-e69f-4810-8965-d5bb42d4763b","local_id":"bJFn7Z5NNLZTydFyx-iJBw-ce50e32c6b48","log_state":false,"name":"BleBox Labor","nickname":null,"permission_holders":{"admin_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"execute_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"read_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"write_users":["6219dc42-b8d0-4b42-851a-1c5956149944"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"firmware","origin":"","value":"1.88.1"},{"key":"firmware","origin":"local-mgw","value":"1.88.1"},{"key":"manufacturer","origin":"","value":"Signify Netherlands B.V."},{"key":"manufacturer","origin":"local-mgw","value":"Signify Netherlands B.V."},{"key":"model","origin":"","value":"LTW010"},{"key":"model","origin":"local-mgw","value":"LTW010"}],"creator":"93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Color temperature light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","name":"Philips Color Temperature Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:d151ced8-1b76-4220-845b-8c5bbdcc7538","urn:infai:ses:service:0bd4b3e8-a7c2-4e90-b0b9-982c7591f454","urn:infai:ses:service:f22a9a47-6c56-461f-b594-db7fc0047b17","urn:infai:ses:service:5a182b68-7818-4bef-ad16-c01f4f0abafc","urn:infai:ses:service:0b7d13ef-43f0-4ae3-a38f-be1b05eeb944","urn:infai:ses:service:c1039edc-d711-4bf7-97fd-08ac8023e7ab","urn:infai:ses:service:eb6096c3-876c-4816-ba58-ec18c227aa81"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","display_name":"Br-Steh-01","id":"urn:infai:ses:device:a1740044-4aa5-4669-b8dc-7dd22482fc56","local_id":"bce47779-d947-4813-8ae4-78667c76546b-00:17:88:01:03:20:03:80-0b","log_state":true,"name":"Br-Steh-01","nickname":null,"permission_holders":{"admin_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"execute_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"read_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"write_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"firmware","origin":"","value":"1.88.1"},{"key":"firmware","origin":"local-mgw","value":"1.88.1"},{"key":"manufacturer","origin":"","value":"Signify Netherlands B.V."},{"key":"manufacturer","origin":"local-mgw","value":"Signify Netherlands B.V."},{"key":"model","origin":"","value":"LTW010"},{"key":"model","origin":"local-mgw","value":"LTW010"}],"creator":"93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Color temperature light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","name":"Philips Color Temperature Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:d151ced8-1b76-4220-845b-8c5bbdcc7538","urn:infai:ses:service:0bd4b3e8-a7c2-4e90-b0b9-982c7591f454","urn:infai:ses:service:f22a9a47-6c56-461f-b594-db7fc0047b17","urn:infai:ses:service:5a182b68-7818-4bef-ad16-c01f4f0abafc","urn:infai:ses:service:0b7d13ef-43f0-4ae3-a38f-be1b05eeb944","urn:infai:ses:service:c1039edc-d711-4bf7-97fd-08ac8023e7ab","urn:infai:ses:service:eb6096c3-876c-4816-ba58-ec18c227aa81"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","display_name":"Br-Tisch-01","id":"urn:infai:ses:device:152ad3d6-6b5e-4bd2-b553-1670a240906c","local_id":"bce47779-d947-4813-8ae4-78667c76546b-00:17:88:01:03:59:ce:e2-0b","log_state":true,"name":"Br-Tisch-01","nickname":null,"permission_holders":{"admin_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"execute_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"read_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"write_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"firmware","origin":"","value":"6.1.1.28573"},{"key":"firmware","origin":"local-mgw","value":"6.1.1.28573"},{"key":"manufacturer","origin":"","value":"Signify Netherlands B.V."},{"key":"manufacturer","origin":"local-mgw","value":"Signify Netherlands B.V."},{"key":"model","origin":"","value":"RWL021"},{"key":"model","origin":"local-mgw","value":"RWL021"}],"creator":"93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:767384f1-83d4-4bed-b127-fc89731298f8","id":"urn:infai:ses:device-type:00a3d158-2161-49ca-ab70-53c2f80a78c2","name":"Hue switch","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0acf0d08-f185-46e3-bf5f-971c8b3a964b","urn:infai:ses:service:8ed12353-e103-44cf-a5a8-fae075de7f75"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:00a3d158-2161-49ca-ab70-53c2f80a78c2","display_name":"Büro","id":"urn:infai:ses:device:b46ab726-3a2d-47c7-9488-f269257f8373","local_id":"bce47779-d947-4813-8ae4-78667c76546b-00:17:88:01:03:a5:1b:4a-02-fc00","log_state":true,"name":"Büro","nickname":null,"permission_holders":{"admin_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"execute_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"read_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"write_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"firmware","origin":"","value":"1.93.11"},{"key":"firmware","origin":"local-mgw","value":"1.93.11"},{"key":"manufacturer","origin":"","value":"Signify Netherlands B.V."},{"key":"manufacturer","origin":"local-mgw","value":"Signify Netherlands B.V."},{"key":"model","origin":"","value":"440400982842"},{"key":"model","origin":"local-mgw","value":"440400982842"}],"creator":"93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Extended Color Light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","name":"Philips Extended Color Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:ba338bca-15d5-4041-a2a9-6960b270b113","urn:infai:ses:service:510941da-4f18-403f-9c28-8ba686439ec6","urn:infai:ses:service:86d8a826-f875-41c9-97ea-632e6b735609","urn:infai:ses:service:3118d662-77d4-436e-8274-8430b3bce54f","urn:infai:ses:service:88d87961-92d6-40c5-9a93-5da7519c7057","urn:infai:ses:service:dc9c8ccc-6bf0-43a3-8173-3f3987ac7040","urn:infai:ses:service:1ddd91b4-b86f-4042-9275-3b88a7cd17a7","urn:infai:ses:service:d150a92a-6b8d-41a5-ba1c-c71847a3b722","urn:infai:ses:service:da5e4f27-040b-43e0-a8d4-d5c8352803ea"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","display_name":"Büro Ambiente links color","id":"urn:infai:ses:device:ef772770-f342-44f2-8dfc-4a98c79e4a42","local_id":"bce47779-d947-4813-8ae4-78667c76546b-00:17:88:01:0b:4a:f0:2f-0b","log_state":true,"name":"Büro Ambiente links color","nickname":null,"permission_holders":{"admin_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"execute_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"read_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"write_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"firmware","origin":"","value":"1.93.11"},{"key":"firmware","origin":"local-mgw","value":"1.93.11"},{"key":"manufacturer","origin":"","value":"Signify Netherlands B.V."},{"key":"manufacturer","origin":"local-mgw","value":"Signify Netherlands B.V."},{"key":"model","origin":"","value":"440400982842"},{"key":"model","origin":"local-mgw","value":"440400982842"}],"creator":"93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Extended Color Light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","name":"Philips Extended Color Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:ba338bca-15d5-4041-a2a9-6960b270b113","urn:infai:ses:service:510941da-4f18-403f-9c28-8ba686439ec6","urn:infai:ses:service:86d8a826-f875-41c9-97ea-632e6b735609","urn:infai:ses:service:3118d662-77d4-436e-8274-8430b3bce54f","urn:infai:ses:service:88d87961-92d6-40c5-9a93-5da7519c7057","urn:infai:ses:service:dc9c8ccc-6bf0-43a3-8173-3f3987ac7040","urn:infai:ses:service:1ddd91b4-b86f-4042-9275-3b88a7cd17a7","urn:infai:ses:service:d150a92a-6b8d-41a5-ba1c-c71847a3b722","urn:infai:ses:service:da5e4f27-040b-43e0-a8d4-d5c8352803ea"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:1d0e8fd1-5db3-4f68-81ea-fd7514cd3852","display_name":"Büro Ambiente rechts color","id":"urn:infai:ses:device:f3d7ea36-dc3a-4e5d-a144-5f55e94b43cc","local_id":"bce47779-d947-4813-8ae4-78667c76546b-00:17:88:01:0b:4a:f0:5b-0b","log_state":true,"name":"Büro Ambiente rechts color","nickname":null,"permission_holders":{"admin_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"execute_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"read_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"write_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"firmware","origin":"","value":"1.88.1"},{"key":"firmware","origin":"local-mgw","value":"1.88.1"},{"key":"manufacturer","origin":"","value":"Signify Netherlands B.V."},{"key":"manufacturer","origin":"local-mgw","value":"Signify Netherlands B.V."},{"key":"model","origin":"","value":"LTW010"},{"key":"model","origin":"local-mgw","value":"LTW010"}],"creator":"93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Color temperature light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","name":"Philips Color Temperature Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:d151ced8-1b76-4220-845b-8c5bbdcc7538","urn:infai:ses:service:0bd4b3e8-a7c2-4e90-b0b9-982c7591f454","urn:infai:ses:service:f22a9a47-6c56-461f-b594-db7fc0047b17","urn:infai:ses:service:5a182b68-7818-4bef-ad16-c01f4f0abafc","urn:infai:ses:service:0b7d13ef-43f0-4ae3-a38f-be1b05eeb944","urn:infai:ses:service:c1039edc-d711-4bf7-97fd-08ac8023e7ab","urn:infai:ses:service:eb6096c3-876c-4816-ba58-ec18c227aa81"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","display_name":"Büro Decke","id":"urn:infai:ses:device:85fbcd06-b391-422e-bb54-dec4d60560bf","local_id":"bce47779-d947-4813-8ae4-78667c76546b-00:17:88:01:03:87:c5:47-0b","log_state":true,"name":"Büro Decke","nickname":null,"permission_holders":{"admin_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"execute_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"read_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"write_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"shared/nickname","origin":"shared","value":"CO Sensor"}],"creator":"68ad39c3-2920-449a-944d-8007f254bf07","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:ff64280a-58e6-4cf9-9a44-e70d3831a79d","id":"urn:infai:ses:device-type:c023e15c-5c3c-49b9-b709-8a0ac84a8b84","name":"Fibaro CO Sensor","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:f9c11350-d5a7-4ae1-bc96-53720d254b56","urn:infai:ses:service:bc4a7490-ffd9-4aa9-95a5-e815af6a919b","urn:infai:ses:service:7588dc2c-c9ab-4555-b5da-30d3d42d7bf4","urn:infai:ses:service:f8bceb74-51a7-45b7-ac79-2e018537cc06","urn:infai:ses:service:45bb2b36-0889-4b9e-a015-ef28c9945b42","urn:infai:ses:service:6f94e6c3-dbdd-486e-ba67-28c336c79391"],"service_groups":[{"description":"","key":"d7cbcaf3-d1a5-467c-ad6b-1a5942caec34","name":"Carbon Monoxide"},{"description":"","key":"679f2fea-9a39-4ca9-b943-48cb112d3b3a","name":"Temperature"}],"shared":false},"device_type_id":"urn:infai:ses:device-type:c023e15c-5c3c-49b9-b709-8a0ac84a8b84","display_name":"CO Sensor","id":"urn:infai:ses:device:2dadc698-34e0-4906-b7bf-9ada6e1437a2","local_id":"afe13fbe4f9c415da4b09137af87b36e:3","log_state":true,"name":"FGSD001 CO Sensor (3)","nickname":"CO Sensor","permission_holders":{"admin_users":["68ad39c3-2920-449a-944d-8007f254bf07"],"execute_users":["68ad39c3-2920-449a-944d-8007f254bf07"],"read_users":["68ad39c3-2920-449a-944d-8007f254bf07"],"write_users":["68ad39c3-2920-449a-944d-8007f254bf07"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"zwavejs","device_class_id":"urn:infai:ses:device-class:ff64280a-58e6-4cf9-9a44-e70d3831a79d","id":"urn:infai:ses:device-type:26078810-305a-453b-ade1-8866a835a4ee","name":"MCO Home CO2 Monitor (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:dd241da1-0ff1-4c28-9333-12ee305f8183","urn:infai:ses:service:ce3414f8-7e09-4b00-ac37-1d678b50b406","urn:infai:ses:service:a5d8bace-5395-4b9b-9b95-def47364d354","urn:infai:ses:service:e6362197-a7a6-44ff-8f29-a0a3aceed36b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:26078810-305a-453b-ade1-8866a835a4ee","display_name":"CO2_Monitor_-_Wohnzimmer_50","id":"urn:infai:ses:device:4bcf9c7c-999d-4ea8-b0a8-c9e6d1958ab7","local_id":"5bbfd68e817a40129138ed8dc8547649:50","log_state":false,"name":"CO2_Monitor_-_Wohnzimmer_50","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":[{"key":"manufacturer","origin":"","value":"innr"},{"key":"model","origin":"","value":"RS 128 T"},{"key":"firmware","origin":"","value":"2.1"}],"creator":"93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Color temperature light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","name":"Philips Color Temperature Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:d151ced8-1b76-4220-845b-8c5bbdcc7538","urn:infai:ses:service:0bd4b3e8-a7c2-4e90-b0b9-982c7591f454","urn:infai:ses:service:f22a9a47-6c56-461f-b594-db7fc0047b17","urn:infai:ses:service:5a182b68-7818-4bef-ad16-c01f4f0abafc","urn:infai:ses:service:0b7d13ef-43f0-4ae3-a38f-be1b05eeb944","urn:infai:ses:service:c1039edc-d711-4bf7-97fd-08ac8023e7ab","urn:infai:ses:service:eb6096c3-876c-4816-ba58-ec18c227aa81"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","display_name":"Color temperature light 1","id":"urn:infai:ses:device:b84b4242-acc0-4d3e-ae78-2fcb4368cc97","local_id":"bce47779-d947-4813-8ae4-78667c76546b-00:15:8d:00:02:10:4e:91-01","log_state":false,"name":"Color temperature light 1","nickname":null,"permission_holders":{"admin_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"execute_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"read_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"write_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":[{"key":"manufacturer","origin":"","value":"innr"},{"key":"model","origin":"","value":"RS 128 T"},{"key":"firmware","origin":"","value":"2.1"}],"creator":"93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"Philips Hue Color temperature light","device_class_id":"urn:infai:ses:device-class:14e56881-16f9-4120-bb41-270a43070c86","id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","name":"Philips Color Temperature Light","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:d151ced8-1b76-4220-845b-8c5bbdcc7538","urn:infai:ses:service:0bd4b3e8-a7c2-4e90-b0b9-982c7591f454","urn:infai:ses:service:f22a9a47-6c56-461f-b594-db7fc0047b17","urn:infai:ses:service:5a182b68-7818-4bef-ad16-c01f4f0abafc","urn:infai:ses:service:0b7d13ef-43f0-4ae3-a38f-be1b05eeb944","urn:infai:ses:service:c1039edc-d711-4bf7-97fd-08ac8023e7ab","urn:infai:ses:service:eb6096c3-876c-4816-ba58-ec18c227aa81"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:97b985af-ff51-4fb7-9360-1c25526096a9","display_name":"Color temperature light 2","id":"urn:infai:ses:device:a2cf051d-dbf6-498b-9163-d9aa2c7a898d","local_id":"bce47779-d947-4813-8ae4-78667c76546b-00:15:8d:00:02:0b:22:86-01","log_state":false,"name":"Color temperature light 2","nickname":null,"permission_holders":{"admin_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"execute_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"read_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"],"write_users":["93b422a2-1ea5-4c11-8f1c-d3e3711c3eb7"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"optimise-mobile-app/favorite","origin":"optimise-mobile-app","value":"true"},{"key":"shared/nickname","origin":"shared","value":"Daikin Madoka EG Master"}],"creator":"f5c70e8a-c586-4eb8-8367-2f6df87a1058","device_type":{"attributes":[{"key":"senergy/local-mqtt","origin":"web-ui","value":"true"}],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"BRC1H Product Line","device_class_id":"urn:infai:ses:device-class:997937d6-c5f3-4486-b67c-114675038393","id":"urn:infai:ses:device-type:d60dc232-7a9f-4bd6-8fc1-1a982ae7722b","name":"Daikin Madoka","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:eca8c45e-7df1-4776-a076-685dd9cd3ea7","urn:infai:ses:service:c1dd5260-eba8-4ad2-a082-11c1a1632b5c","urn:infai:ses:service:9c428f45-fecb-4db5-b421-c543e77a5544","urn:infai:ses:service:2559107f-ac36-4c8e-8df8-becceb2c4b7a","urn:infai:ses:service:44ad4045-7ca1-4731-8152-fe372dc1235c","urn:infai:ses:service:10fa52bd-9003-4cf9-8b47-01a6d45547b3"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:d60dc232-7a9f-4bd6-8fc1-1a982ae7722b","display_name":"Daikin Madoka EG Master","id":"urn:infai:ses:device:d2adb31a-b36a-4945-ab58-094238d6c249","local_id":"BRC1H_30_E5_6C","log_state":true,"name":"","nickname":"Daikin Madoka EG Master","permission_holders":{"admin_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"execute_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"read_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"write_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"attributes":null,"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","device_type":{"attributes":null,"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:997937d6-c5f3-4486-b67c-114675038393","id":"urn:infai:ses:device-type:9ae1f9eb-ebd6-4fb5-ae1f-a03d40c500ed","name":"Devolo Radiator Thermostat (deprecated)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:ec5f637c-9a78-4928-b6c9-b3047b9dc2f3","urn:infai:ses:service:da7431de-f566-4a74-8e30-f19407f527bd","urn:infai:ses:service:e6566b71-3578-40bb-b4e1-92632e625e3a","urn:infai:ses:service:39415c76-93a3-4e8d-8740-d1a83c64bddc"],"service_groups":null,"shared":false},"device_type_id":"urn:infai:ses:device-type:9ae1f9eb-ebd6-4fb5-ae1f-a03d40c500ed","display_name":"Devolo Radiator Thermostat (#43)","id":"urn:infai:ses:device:8d524aac-ea07-4842-8e67-184b4c42ef06","local_id":"e3a7a0a7f35c9c9615839eca59db5b7d-43","name":"Devolo Radiator Thermostat (#43)","nickname":null,"permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":false},{"attributes":null,"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","device_type":{"attributes":null,"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:997937d6-c5f3-4486-b67c-114675038393","id":"urn:infai:ses:device-type:9ae1f9eb-ebd6-4fb5-ae1f-a03d40c500ed","name":"Devolo Radiator Thermostat (deprecated)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:ec5f637c-9a78-4928-b6c9-b3047b9dc2f3","urn:infai:ses:service:da7431de-f566-4a74-8e30-f19407f527bd","urn:infai:ses:service:e6566b71-3578-40bb-b4e1-92632e625e3a","urn:infai:ses:service:39415c76-93a3-4e8d-8740-d1a83c64bddc"],"service_groups":null,"shared":false},"device_type_id":"urn:infai:ses:device-type:9ae1f9eb-ebd6-4fb5-ae1f-a03d40c500ed","display_name":"Devolo Radiator Thermostat (#49)","id":"urn:infai:ses:device:8db83a7f-b9dd-4347-85b0-b67d5034183f","local_id":"e3a7a0a7f35c9c9615839eca59db5b7d-49","name":"Devolo Radiator Thermostat (#49)","nickname":null,"permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":false},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:997937d6-c5f3-4486-b67c-114675038393","id":"urn:infai:ses:device-type:27132ce1-c57d-459c-a1c0-a17d4f0ee972","name":"Danfoss Radiator Thermostat v2(zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:30e277c2-9831-42c2-b85c-ebfd8feb1f57","urn:infai:ses:service:d4792f2f-e53c-4f1d-aff2-fd1c1de9d553","urn:infai:ses:service:ffdf593d-f303-4353-92dd-bdc1cdf13bb1","urn:infai:ses:service:945b566d-c673-406d-9adf-1064200ffad9"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:27132ce1-c57d-459c-a1c0-a17d4f0ee972","display_name":"Devolo Thermostat (09356) (56)","id":"urn:infai:ses:device:1f490692-7686-4a79-ad89-239a052902d3","local_id":"5bbfd68e817a40129138ed8dc8547649:56","log_state":false,"name":"Devolo Thermostat (09356) (56)","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"attributes":null,"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"to be extended","device_class_id":"urn:infai:ses:device-class:79de1bd9-b933-412d-b98e-4cfe19aa3250","id":"urn:infai:ses:device-type:1c200f02-67ac-42e1-8c6c-748bdc091764","name":"Devolo Wall Plug (deprecated)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:33394eae-9df1-47d0-b115-adfcb0a50ff6","urn:infai:ses:service:3aaddac5-223b-4cd6-b9d1-b17b25b7ac0f","urn:infai:ses:service:642ba3c3-5c7a-40f1-a134-4d6fd4d6cd95","urn:infai:ses:service:eaa2284a-b0c9-48ab-9d94-9248d4a2e3cb","urn:infai:ses:service:c5f9cd86-3049-43fb-8a16-071b10e983ea"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:1c200f02-67ac-42e1-8c6c-748bdc091764","display_name":"Devolo Wall Plug 2.0 (#50)","id":"urn:infai:ses:device:f46c6434-4724-441e-b202-ef0c1de5f5bc","local_id":"e3a7a0a7f35c9c9615839eca59db5b7d-50","name":"Devolo Wall Plug 2.0 (#50)","nickname":null,"permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":false},{"attributes":null,"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"to be extended","device_class_id":"urn:infai:ses:device-class:79de1bd9-b933-412d-b98e-4cfe19aa3250","id":"urn:infai:ses:device-type:1c200f02-67ac-42e1-8c6c-748bdc091764","name":"Devolo Wall Plug (deprecated)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:33394eae-9df1-47d0-b115-adfcb0a50ff6","urn:infai:ses:service:3aaddac5-223b-4cd6-b9d1-b17b25b7ac0f","urn:infai:ses:service:642ba3c3-5c7a-40f1-a134-4d6fd4d6cd95","urn:infai:ses:service:eaa2284a-b0c9-48ab-9d94-9248d4a2e3cb","urn:infai:ses:service:c5f9cd86-3049-43fb-8a16-071b10e983ea"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:1c200f02-67ac-42e1-8c6c-748bdc091764","display_name":"Devolo Wall Plug 2.0 (#51)","id":"urn:infai:ses:device:af5a66a7-c270-4680-a2ae-4fc0875412c4","local_id":"e3a7a0a7f35c9c9615839eca59db5b7d-51","name":"Devolo Wall Plug 2.0 (#51)","nickname":null,"permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":false},{"annotations":{"connected":true},"attributes":[{"key":"optimise-mobile-app/favorite","origin":"optimise-mobile-app","value":"true"},{"key":"shared/nickname","origin":"shared","value":"Door/Window Sensor EG 1"}],"creator":"f5c70e8a-c586-4eb8-8367-2f6df87a1058","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door/Window Sensor EG 1","id":"urn:infai:ses:device:d5ebd020-f43d-4c69-9aab-3c4de3b7d2ff","local_id":"A01012207000764:2","log_state":true,"name":"O-03","nickname":"Door/Window Sensor EG 1","permission_holders":{"admin_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"execute_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"read_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"write_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"shared/nickname","origin":"shared","value":"Door/Window Sensor EG 2"}],"creator":"f5c70e8a-c586-4eb8-8367-2f6df87a1058","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door/Window Sensor EG 2","id":"urn:infai:ses:device:e0328fe8-2e51-485a-9db2-5563ef85c42b","local_id":"A01012207000764:3","log_state":true,"name":"O-04","nickname":"Door/Window Sensor EG 2","permission_holders":{"admin_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"execute_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"read_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"write_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"shared/nickname","origin":"shared","value":"Door/Window Sensor EG 3"}],"creator":"f5c70e8a-c586-4eb8-8367-2f6df87a1058","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door/Window Sensor EG 3","id":"urn:infai:ses:device:3206ba3c-4d72-42e9-9893-d2659d31f63a","local_id":"A01012207000764:4","log_state":true,"name":"O-05","nickname":"Door/Window Sensor EG 3","permission_holders":{"admin_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"execute_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"read_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"write_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"shared/nickname","origin":"shared","value":"Door/Window Sensor EG 4"}],"creator":"f5c70e8a-c586-4eb8-8367-2f6df87a1058","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door/Window Sensor EG 4","id":"urn:infai:ses:device:785c5daf-ff78-4cf9-a104-50d3873c11c6","local_id":"A01012207000764:5","log_state":true,"name":"O-06","nickname":"Door/Window Sensor EG 4","permission_holders":{"admin_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"execute_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"read_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"write_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":true},"attributes":[{"key":"shared/nickname","origin":"shared","value":"Door/Window Sensor EG 5"}],"creator":"f5c70e8a-c586-4eb8-8367-2f6df87a1058","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door/Window Sensor EG 5","id":"urn:infai:ses:device:46917b9d-fa49-4583-b786-a94e76c14f3a","local_id":"A01012207000764:6","log_state":true,"name":"O-07","nickname":"Door/Window Sensor EG 5","permission_holders":{"admin_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"execute_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"read_users":["172dd8bd-dca6-4339-9c8f-6979190a0017","f5c70e8a-c586-4eb8-8367-2f6df87a1058"],"write_users":["f5c70e8a-c586-4eb8-8367-2f6df87a1058"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"6219dc42-b8d0-4b42-851a-1c5956149944","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:8d10ebe7-b633-481e-8c7f-c57b5eb8df7d","name":"Devolo Door/Window Contact (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:725a8084-8fa6-4058-a114-d73cda8f7123","urn:infai:ses:service:b7943ee5-1b12-42e2-ada2-6b14844a1df5","urn:infai:ses:service:86eef44d-a235-446f-b36f-e09e70bfa51c","urn:infai:ses:service:1540e4ed-5190-489d-9f44-c366a19c1771"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:8d10ebe7-b633-481e-8c7f-c57b5eb8df7d","display_name":"Door_Sensor_-_Eingangstuer_25","id":"urn:infai:ses:device:1f5e1b8a-b386-43ee-9a79-8342568d1675","local_id":"d41b936021e541eddf0ab28307306cc7:25","log_state":false,"name":"Door_Sensor_-_Eingangstuer_25","nickname":null,"permission_holders":{"admin_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"execute_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"read_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"write_users":["6219dc42-b8d0-4b42-851a-1c5956149944"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"6219dc42-b8d0-4b42-851a-1c5956149944","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:8d10ebe7-b633-481e-8c7f-c57b5eb8df7d","name":"Devolo Door/Window Contact (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:725a8084-8fa6-4058-a114-d73cda8f7123","urn:infai:ses:service:b7943ee5-1b12-42e2-ada2-6b14844a1df5","urn:infai:ses:service:86eef44d-a235-446f-b36f-e09e70bfa51c","urn:infai:ses:service:1540e4ed-5190-489d-9f44-c366a19c1771"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:8d10ebe7-b633-481e-8c7f-c57b5eb8df7d","display_name":"Door_Sensor_-_Kuehlschrank_26","id":"urn:infai:ses:device:b1e7fad3-e17d-4e47-9ed3-877545935ea8","local_id":"d41b936021e541eddf0ab28307306cc7:26","log_state":false,"name":"Door_Sensor_-_Kuehlschrank_26","nickname":null,"permission_holders":{"admin_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"execute_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"read_users":["6219dc42-b8d0-4b42-851a-1c5956149944"],"write_users":["6219dc42-b8d0-4b42-851a-1c5956149944"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Bad_gross_Fenster_51","id":"urn:infai:ses:device:8621bffd-bcba-48f4-824c-4dfc3c70de2d","local_id":"5bbfd68e817a40129138ed8dc8547649:51","log_state":false,"name":"Door_Window_Sensor_-_Bad_gross_Fenster_51","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Bad_gross_Tuer_09","id":"urn:infai:ses:device:3f488292-5c90-4a62-9a73-357973603238","local_id":"5bbfd68e817a40129138ed8dc8547649:9","log_state":false,"name":"Door_Window_Sensor_-_Bad_gross_Tuer_09","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Bad_klein_Tuer_11","id":"urn:infai:ses:device:56530208-b993-4920-9577-5a9d65482745","local_id":"5bbfd68e817a40129138ed8dc8547649:11","log_state":false,"name":"Door_Window_Sensor_-_Bad_klein_Tuer_11","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Kinderzimmer_I_Fenster_18","id":"urn:infai:ses:device:4d31f560-f0b7-4f24-93e2-dc597ecfda6a","local_id":"5bbfd68e817a40129138ed8dc8547649:18","log_state":false,"name":"Door_Window_Sensor_-_Kinderzimmer_I_Fenster_18","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Kinderzimmer_I_Tuer_17","id":"urn:infai:ses:device:eb223a40-bc3e-41d8-9576-a16728d37ad5","local_id":"5bbfd68e817a40129138ed8dc8547649:17","log_state":false,"name":"Door_Window_Sensor_-_Kinderzimmer_I_Tuer_17","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Kinderzimmer_II_Fenster_08","id":"urn:infai:ses:device:d5928701-ed37-4aa6-9652-cea08a555bab","local_id":"5bbfd68e817a40129138ed8dc8547649:8","log_state":false,"name":"Door_Window_Sensor_-_Kinderzimmer_II_Fenster_08","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Kinderzimmer_II_Tuer_07","id":"urn:infai:ses:device:33a5a8fc-a4e8-45bd-b856-79cac3a44f4f","local_id":"5bbfd68e817a40129138ed8dc8547649:7","log_state":false,"name":"Door_Window_Sensor_-_Kinderzimmer_II_Tuer_07","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Kueche_Balkontuer_13","id":"urn:infai:ses:device:5d08af54-79c5-4362-9ad4-c7cd9bb68473","local_id":"5bbfd68e817a40129138ed8dc8547649:13","log_state":false,"name":"Door_Window_Sensor_-_Kueche_Balkontuer_13","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Kueche_Fenster_14","id":"urn:infai:ses:device:356266db-418e-429f-a764-db5b7ed31e5e","local_id":"5bbfd68e817a40129138ed8dc8547649:14","log_state":false,"name":"Door_Window_Sensor_-_Kueche_Fenster_14","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Kueche_Kuehlschrank_15","id":"urn:infai:ses:device:37be60c7-f83f-487b-95b1-2add20115103","local_id":"5bbfd68e817a40129138ed8dc8547649:15","log_state":false,"name":"Door_Window_Sensor_-_Kueche_Kuehlschrank_15","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Kueche_Tuer_04","id":"urn:infai:ses:device:ca16e695-819b-4943-b8e4-fb9c53a33abe","local_id":"5bbfd68e817a40129138ed8dc8547649:4","log_state":false,"name":"Door_Window_Sensor_-_Kueche_Tuer_04","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Schlafzimmer_Fenster_ links_02","id":"urn:infai:ses:device:73da97fb-c8e2-45a4-8873-0d114b7e8989","local_id":"5bbfd68e817a40129138ed8dc8547649:2","log_state":false,"name":"Door_Window_Sensor_-_Schlafzimmer_Fenster_ links_02","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Schlafzimmer_Fenster_ rechts_03","id":"urn:infai:ses:device:d8bfbb2e-164c-437e-98e8-ebe560dbe872","local_id":"5bbfd68e817a40129138ed8dc8547649:3","log_state":false,"name":"Door_Window_Sensor_-_Schlafzimmer_Fenster_ rechts_03","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Schlafzimmer_Tuer_06","id":"urn:infai:ses:device:044dcf59-0733-4a2c-9b9f-025cb3127daa","local_id":"5bbfd68e817a40129138ed8dc8547649:6","log_state":false,"name":"Door_Window_Sensor_-_Schlafzimmer_Tuer_06","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true},{"annotations":{"connected":false},"attributes":null,"creator":"8db5bb9f-c122-403a-a320-9fd94858c1a9","device_type":{"attributes":[],"creator":"dd69ea0d-f553-4336-80f3-7f4567f85c7b","description":"","device_class_id":"urn:infai:ses:device-class:42c623c9-977b-4c77-ba64-80fdbc5becc0","id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","name":"Aeotec Door/Window Sensor 7 (zwavejs)","permission_holders":{"admin_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"execute_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"read_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"],"write_users":["dd69ea0d-f553-4336-80f3-7f4567f85c7b"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"protocols":["urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b","urn:infai:ses:protocol:f3a63aeb-187e-4dd9-9ef5-d97a6eb6292b"],"service":["urn:infai:ses:service:0ba3ce58-f184-4422-8d74-8afe62714da6","urn:infai:ses:service:e077eb4f-756f-4822-9da6-dcffe4f36de6","urn:infai:ses:service:2e93a09e-66a2-400e-81b8-0ffad9f7d772","urn:infai:ses:service:083f7efb-85cc-440a-9d39-f0de53207a8b"],"service_groups":[],"shared":false},"device_type_id":"urn:infai:ses:device-type:c5584a6a-75f3-4867-b2d6-ef108d4d6c96","display_name":"Door_Window_Sensor_-_Wohnung_Tuer_16","id":"urn:infai:ses:device:d3c317a5-2c6a-4cbd-8f43-25a17fd879da","local_id":"5bbfd68e817a40129138ed8dc8547649:16","log_state":false,"name":"Door_Window_Sensor_-_Wohnung_Tuer_16","nickname":null,"permission_holders":{"admin_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"execute_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"read_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"],"write_users":["8db5bb9f-c122-403a-a320-9fd94858c1a9"]},"permissions":{"a":true,"r":true,"w":true,"x":true},"shared":true}]
@@ -49,3 +52,298 @@ func TestDeviceIdHash(t *testing.T) {
 		return
 	}
 }
+
+func TestJsonPathHash(t *testing.T) {
+	options := model.HashOptions{HashPaths: []string{"$.devices[*].id", "$.state"}}
+	hashOf := func(t *testing.T, payload string) string {
+		h, err := hashWithOptions(HASH_TYPE_JSONPATH, options, []byte(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	base := hashOf(t, `{"devices":[{"id":"a","last_seen":1},{"id":"b","last_seen":2}],"state":{"x":1,"y":2},"updated_at":"2026-01-01"}`)
+
+	t.Run("unselected fields are ignored", func(t *testing.T) {
+		h := hashOf(t, `{"devices":[{"id":"a","last_seen":3},{"id":"b","last_seen":4}],"state":{"x":1,"y":2},"updated_at":"2026-01-02"}`)
+		if h != base {
+			t.Error(h, base)
+		}
+	})
+
+	t.Run("key order is canonical", func(t *testing.T) {
+		h := hashOf(t, `{"state":{"y":2,"x":1},"updated_at":"2026-01-01","devices":[{"last_seen":1,"id":"a"},{"id":"b"}]}`)
+		if h != base {
+			t.Error(h, base)
+		}
+	})
+
+	t.Run("selected changes are detected", func(t *testing.T) {
+		if h := hashOf(t, `{"devices":[{"id":"a"},{"id":"c"}],"state":{"x":1,"y":2}}`); h == base {
+			t.Error("device change not detected")
+		}
+		if h := hashOf(t, `{"devices":[{"id":"a"},{"id":"b"}],"state":{"x":1,"y":3}}`); h == base {
+			t.Error("state change not detected")
+		}
+		if h := hashOf(t, `{"devices":[{"id":"a"},{"id":"b"}]}`); h == base {
+			t.Error("removed state not detected")
+		}
+	})
+
+	t.Run("values of different paths are distinguished", func(t *testing.T) {
+		a, err := hashWithOptions(HASH_TYPE_JSONPATH, model.HashOptions{HashPaths: []string{"$.a", "$.b"}}, []byte(`{"a":1}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := hashWithOptions(HASH_TYPE_JSONPATH, model.HashOptions{HashPaths: []string{"$.a", "$.b"}}, []byte(`{"b":1}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a == b {
+			t.Error(a, b)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := hashWithOptions(HASH_TYPE_JSONPATH, model.HashOptions{}, []byte(`{}`))
+		if err == nil {
+			t.Error("missing paths accepted")
+		}
+		_, err = hashWithOptions(HASH_TYPE_JSONPATH, model.HashOptions{HashPaths: []string{"devices"}}, []byte(`{}`))
+		if err == nil {
+			t.Error("invalid path accepted")
+		}
+		_, err = hashWithOptions(HASH_TYPE_JSONPATH, options, []byte(`not json`))
+		if err == nil {
+			t.Error("invalid payload accepted")
+		}
+	})
+}
+
+func TestJsonCanonicalHash(t *testing.T) {
+	hashOf := func(t *testing.T, options model.HashOptions, payload string) string {
+		h, err := hashWithOptions(HASH_TYPE_JSON_CANONICAL, options, []byte(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	base := `{"id":"urn:infai:ses:device:1","name":"lamp","attributes":[{"key":"a","value":1.5},{"key":"b","value":100}],"online":true,"parent":null}`
+	baseHash := hashOf(t, model.HashOptions{}, base)
+
+	equal := map[string]string{
+		"reordered keys": `{"online":true,"parent":null,"attributes":[{"value":1.5,"key":"a"},{"value":100,"key":"b"}],"name":"lamp","id":"urn:infai:ses:device:1"}`,
+		"reformatted": `{
+			"id": "urn:infai:ses:device:1",
+			"name": "lamp",
+			"attributes": [ { "key": "a", "value": 1.5 }, { "key": "b", "value": 100 } ],
+			"online": true,
+			"parent": null
+		}`,
+		"number notation": `{"id":"urn:infai:ses:device:1","name":"lamp","attributes":[{"key":"a","value":15e-1},{"key":"b","value":1.00E2}],"online":true,"parent":null}`,
+		"escaped strings": `{"id":"urn:infai:ses:device:1","name":"lamp","attributes":[{"key":"a","value":1.5},{"key":"b","value":100}],"online":true,"parent":null}`,
+	}
+	for name, payload := range equal {
+		t.Run(name, func(t *testing.T) {
+			if h := hashOf(t, model.HashOptions{}, payload); h != baseHash {
+				t.Error(h, baseHash)
+			}
+		})
+	}
+
+	different := map[string]string{
+		"changed value":    `{"id":"urn:infai:ses:device:1","name":"lamp","attributes":[{"key":"a","value":1.6},{"key":"b","value":100}],"online":true,"parent":null}`,
+		"number as string": `{"id":"urn:infai:ses:device:1","name":"lamp","attributes":[{"key":"a","value":"1.5"},{"key":"b","value":100}],"online":true,"parent":null}`,
+		"missing key":      `{"id":"urn:infai:ses:device:1","name":"lamp","attributes":[{"key":"a","value":1.5},{"key":"b","value":100}],"online":true}`,
+		"reordered array":  `{"id":"urn:infai:ses:device:1","name":"lamp","attributes":[{"key":"b","value":100},{"key":"a","value":1.5}],"online":true,"parent":null}`,
+	}
+	for name, payload := range different {
+		t.Run(name, func(t *testing.T) {
+			if h := hashOf(t, model.HashOptions{}, payload); h == baseHash {
+				t.Error("change not detected")
+			}
+		})
+	}
+
+	t.Run("large integers keep precision", func(t *testing.T) {
+		a := hashOf(t, model.HashOptions{}, `{"counter":9007199254740993}`)
+		b := hashOf(t, model.HashOptions{}, `{"counter":9007199254740992}`)
+		if a == b {
+			t.Error(a, b)
+		}
+	})
+
+	t.Run("number notations", func(t *testing.T) {
+		equal := [][]string{
+			{"1", "1.0", "1e0", "10e-1", "0.1e1", "1.00E+0"},
+			{"0", "-0", "0.0", "0e10", "-0.0e-5"},
+			{"-150", "-1.5e2", "-15E1", "-150.000"},
+			{"0.0012", "1.2e-3", "12e-4", "0.00120"},
+		}
+		for _, numbers := range equal {
+			expected := hashOf(t, model.HashOptions{}, numbers[0])
+			for _, number := range numbers[1:] {
+				if actual := hashOf(t, model.HashOptions{}, number); actual != expected {
+					t.Error(numbers[0], number)
+				}
+			}
+		}
+		if hashOf(t, model.HashOptions{}, "1e1000000") == hashOf(t, model.HashOptions{}, "1e1000001") {
+			t.Error("change not detected")
+		}
+		if hashOf(t, model.HashOptions{}, "-1") == hashOf(t, model.HashOptions{}, "1") {
+			t.Error("change not detected")
+		}
+	})
+
+	t.Run("large exponents are not expanded", func(t *testing.T) {
+		start := time.Now()
+		hashOf(t, model.HashOptions{}, `[1e1000000,-1e-1000000,1e2147483647]`)
+		if duration := time.Since(start); duration > time.Second {
+			t.Error(duration)
+		}
+		_, err := hashWithOptions(HASH_TYPE_JSON_CANONICAL, model.HashOptions{}, []byte(`1e99999999999`))
+		if err == nil {
+			t.Error("exponent out of range accepted")
+		}
+	})
+
+	t.Run("sorted arrays", func(t *testing.T) {
+		options := model.HashOptions{HashSortArrays: true}
+		a := hashOf(t, options, base)
+		b := hashOf(t, options, different["reordered array"])
+		if a != b {
+			t.Error(a, b)
+		}
+		c := hashOf(t, options, `{"list":[[2,1],[3]]}`)
+		d := hashOf(t, options, `{"list":[[3],[1,2]]}`)
+		if c != d {
+			t.Error("nested arrays are not sorted", c, d)
+		}
+		if hashOf(t, options, different["changed value"]) == a {
+			t.Error("change not detected")
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := hashWithOptions(HASH_TYPE_JSON_CANONICAL, model.HashOptions{}, []byte(`{"a":`))
+		if err == nil {
+			t.Error("invalid json accepted")
+		}
+	})
+}
+
+func TestHashIgnoreFields(t *testing.T) {
+	base := `{"devices":[{"id":"d1","last_seen":1,"state":"on"},{"id":"d2","last_seen":2,"state":"off"}],"meta":{"etag":"a","updated_at":"2026-01-01","total":2},"updated_at":"2026-01-01"}`
+	options := model.HashOptions{HashIgnoreFields: []string{"last_seen", "updated_at", "/meta/etag"}}
+
+	equal := map[string]string{
+		"volatile fields changed": `{"devices":[{"id":"d1","last_seen":10,"state":"on"},{"id":"d2","last_seen":20,"state":"off"}],"meta":{"etag":"b","updated_at":"2026-02-01","total":2},"updated_at":"2026-02-01"}`,
+		"volatile fields missing": `{"devices":[{"id":"d1","state":"on"},{"id":"d2","state":"off"}],"meta":{"total":2}}`,
+		"reformatted":             "{\n\"updated_at\": \"x\",\n\"meta\": {\"total\": 2},\n\"devices\": [{\"state\": \"on\", \"id\": \"d1\"}, {\"state\": \"off\", \"id\": \"d2\"}]\n}",
+	}
+	different := map[string]string{
+		"state changed":                   `{"devices":[{"id":"d1","last_seen":1,"state":"off"},{"id":"d2","last_seen":2,"state":"off"}],"meta":{"etag":"a","updated_at":"2026-01-01","total":2},"updated_at":"2026-01-01"}`,
+		"etag outside of pointer changed": `{"devices":[{"id":"d1","last_seen":1,"state":"on","etag":"x"},{"id":"d2","last_seen":2,"state":"off"}],"meta":{"etag":"a","updated_at":"2026-01-01","total":2},"updated_at":"2026-01-01"}`,
+	}
+
+	for _, hashType := range []string{HASH_TYPE_MD5, HASH_TYPE_SHA256, HASH_TYPE_JSON_CANONICAL} {
+		t.Run(hashType, func(t *testing.T) {
+			baseHash, err := hashWithOptions(hashType, options, []byte(base))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for name, payload := range equal {
+				h, err := hashWithOptions(hashType, options, []byte(payload))
+				if err != nil {
+					t.Error(name, err)
+					continue
+				}
+				if h != baseHash {
+					t.Error(name, h, baseHash)
+				}
+			}
+			for name, payload := range different {
+				h, err := hashWithOptions(hashType, options, []byte(payload))
+				if err != nil {
+					t.Error(name, err)
+					continue
+				}
+				if h == baseHash {
+					t.Error(name, "change not detected")
+				}
+			}
+		})
+	}
+
+	t.Run("jsonpath", func(t *testing.T) {
+		options := model.HashOptions{HashPaths: []string{"$.devices"}, HashIgnoreFields: []string{"last_seen"}}
+		a, err := hashWithOptions(HASH_TYPE_JSONPATH, options, []byte(base))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		b, err := hashWithOptions(HASH_TYPE_JSONPATH, options, []byte(equal["volatile fields changed"]))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if a != b {
+			t.Error(a, b)
+		}
+	})
+
+	t.Run("pointer to array element", func(t *testing.T) {
+		options := model.HashOptions{HashIgnoreFields: []string{"/list/0", "/a~1b", "/c~0d"}}
+		a, err := hashWithOptions(HASH_TYPE_JSON_CANONICAL, options, []byte(`{"list":[1,2,3],"a/b":1,"c~d":1}`))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		b, err := hashWithOptions(HASH_TYPE_JSON_CANONICAL, model.HashOptions{}, []byte(`{"list":[2,3]}`))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if a != b {
+			t.Error(a, b)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, field := range []string{"", "/a~2", "/a~"} {
+			if ValidateIgnoreField(field) == nil {
+				t.Errorf("%#v accepted", field)
+			}
+			_, err := hashWithOptions(HASH_TYPE_MD5, model.HashOptions{HashIgnoreFields: []string{field}}, []byte(`{}`))
+			if err == nil {
+				t.Errorf("%#v accepted", field)
+			}
+		}
+		_, err := hashWithOptions(HASH_TYPE_MD5, options, []byte(`not json`))
+		if err == nil {
+			t.Error("invalid json accepted")
+		}
+	})
+}
+
+func TestPredicateHash(t *testing.T) {
+	options := model.HashOptions{Predicate: "battery < 20"}
+	h, err := hashWithOptions(HASH_TYPE_PREDICATE, options, []byte(`{"battery":15}`))
+	if err != nil || h != PREDICATE_TRUE {
+		t.Error(h, err)
+	}
+	h, err = hashWithOptions(HASH_TYPE_PREDICATE, options, []byte(`{"battery":20}`))
+	if err != nil || h != PREDICATE_FALSE {
+		t.Error(h, err)
+	}
+	_, err = hashWithOptions(HASH_TYPE_PREDICATE, model.HashOptions{}, []byte(`{"battery":15}`))
+	if err == nil {
+		t.Error("missing predicate accepted")
+	}
+	_, err = hashWithOptions(HASH_TYPE_PREDICATE, options, []byte(`battery`))
+	if err == nil {
+		t.Error("invalid json accepted")
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidateIgnoreField checks an element of model.HashOptions.HashIgnoreFields.
+// elements starting with "/" are json pointers (RFC 6901), all other elements are field names.
+func ValidateIgnoreField(field string) error {
+	if field == "" {
+		return errors.New("empty hash ignore field")
+	}
+	if strings.HasPrefix(field, "/") {
+		_, err := parseJsonPointer(field)
+		return err
+	}
+	return nil
+}
+
+// removeIgnoredFields removes the ignored fields from a value parsed by parseJson.
+// field names are removed from every object in the tree, json pointers only remove the referenced value.
+// pointers to missing values are ignored.
+func removeIgnoredFields(value interface{}, fields []string) (interface{}, error) {
+	names := map[string]bool{}
+	for _, field := range fields {
+		err := ValidateIgnoreField(field)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(field, "/") {
+			names[field] = true
+		}
+	}
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "/") {
+			continue
+		}
+		tokens, err := parseJsonPointer(field)
+		if err != nil {
+			return nil, err
+		}
+		value = removeJsonPointer(value, tokens)
+	}
+	if len(names) > 0 {
+		value = removeFieldNames(value, names)
+	}
+	return value, nil
+}
+
+func removeFieldNames(value interface{}, names map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, element := range v {
+			if names[key] {
+				delete(v, key)
+			} else {
+				v[key] = removeFieldNames(element, names)
+			}
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = removeFieldNames(element, names)
+		}
+	}
+	return value
+}
+
+func removeJsonPointer(value interface{}, tokens []string) interface{} {
+	if len(tokens) == 0 {
+		return value
+	}
+	token, rest := tokens[0], tokens[1:]
+	switch v := value.(type) {
+	case map[string]interface{}:
+		element, ok := v[token]
+		if !ok {
+			return value
+		}
+		if len(rest) == 0 {
+			delete(v, token)
+		} else {
+			v[token] = removeJsonPointer(element, rest)
+		}
+	case []interface{}:
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(v) || (len(token) > 1 && token[0] == '0') {
+			return value
+		}
+		if len(rest) == 0 {
+			return append(v[:index:index], v[index+1:]...)
+		}
+		v[index] = removeJsonPointer(v[index], rest)
+	}
+	return value
+}
+
+// parseJsonPointer splits a json pointer into its unescaped reference tokens
+func parseJsonPointer(pointer string) (tokens []string, err error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid json pointer %#v: expect leading /", pointer)
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		for i := 0; i < len(token); i++ {
+			if token[i] == '~' && (i+1 >= len(token) || (token[i+1] != '0' && token[i+1] != '1')) {
+				return nil, fmt.Errorf("invalid json pointer %#v: ~ must be followed by 0 or 1", pointer)
+			}
+		}
+		tokens = append(tokens, strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~"))
+	}
+	return tokens, nil
+}
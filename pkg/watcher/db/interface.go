@@ -16,13 +16,67 @@
 
 package db
 
-import "github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+import (
+	"errors"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+)
+
+var ErrNotFound = errors.New("watched entity not found")
+var ErrLeased = errors.New("watched entity is leased by a running check")
 
 type Database interface {
-	Fetch(max int64) ([]model.WatchedEntity, error)
+	// Fetch claims up to max due entities for owner by setting a lease and scheduling their next check.
+	// Entities leased by another owner are skipped until the lease expires.
+	Fetch(owner string, max int64) ([]model.WatchedEntity, error)
+	// ClaimLease leases the entity for owner independent of its next check, e.g. for a manual check, and returns the leased entity.
+	// ErrLeased is returned while any owner, including owner itself, holds an unexpired lease.
+	ClaimLease(owner string, id string, userId string) (model.WatchedEntity, error)
+	// ReleaseLease removes the lease of owner from the entity, leases of other owners are kept.
+	ReleaseLease(owner string, id string, userId string) error
 	UpdateHash(id string, userId string, hash string) error
+	// SetPendingHash stores a detected hash, that is not yet promoted to LastHash because its trigger is not yet delivered
+	SetPendingHash(id string, userId string, hash string) error
+	// PromotePendingHash sets LastHash, LastDeviceIds, ETag and LastModified from result if result.Hash is still the pending hash of the entity.
+	// a not nil outbox trigger is added as pending trigger in the same transaction
+	PromotePendingHash(id string, userId string, result model.CheckResult, outbox *model.PendingTrigger) error
+	// UpdateValidators stores the ETag and Last-Modified headers of an unchanged response
+	UpdateValidators(id string, userId string, etag string, lastModified string) error
+	// UpdateFailureState stores the failure state and the timestamp of the next check
+	UpdateFailureState(id string, userId string, state model.FailureState, timestampOfNextCheck int64) error
+	// SetPaused pauses or resumes the entity without changing its LastHash; paused entities are ignored by Fetch
+	SetPaused(id string, userId string, paused bool) error
 
 	Set(model.WatchedEntityInit) error
 	Read(id string, userId string) (model.WatchedEntity, error)
+	// ListByUser returns the entities of the user; the sort field of query is expected to be one of SortFields
+	ListByUser(userId string, query QueryOptions) ([]model.WatchedEntity, error)
+	// ListBySourceType returns the entities of all users with the source type, e.g. for push sources that subscribe every entity
+	ListBySourceType(sourceType string) ([]model.WatchedEntity, error)
+	// Delete removes the entity, its history and its pending triggers
 	Delete(id string, userId string) error
+
+	// AddHistoryEntry stores the entry and removes the oldest entries of the watcher exceeding config.HistoryRetention
+	AddHistoryEntry(entry model.HistoryEntry) error
+	// ListHistory returns the newest entries of the watcher first; limit <= 0 returns all entries
+	ListHistory(id string, userId string, limit int64) ([]model.HistoryEntry, error)
+
+	AddPendingTrigger(trigger model.PendingTrigger) error
+	// FetchPendingTriggers claims up to max due triggers, that are not dead-lettered, by moving their NextAttempt out by the lease duration
+	FetchPendingTriggers(max int64) ([]model.PendingTrigger, error)
+	// UpdatePendingTrigger replaces the trigger with the same id
+	UpdatePendingTrigger(trigger model.PendingTrigger) error
+	RemovePendingTrigger(id string) error
+	ReadPendingTrigger(id string, userId string) (model.PendingTrigger, error)
+	// ListDeadLetters returns the dead-lettered triggers of the user, oldest first
+	ListDeadLetters(userId string) ([]model.PendingTrigger, error)
 }
+
+type QueryOptions interface {
+	GetLimit() int64
+	GetOffset() int64
+	GetSort() string
+}
+
+// SortFields lists the json field names by which ListByUser can sort, optionally suffixed with ".asc" or ".desc"
+var SortFields = []string{"id", "interval", "hash_type", "created_at", "timestamp_of_next_check"}
@@ -0,0 +1,353 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+)
+
+// Memory is a db.Database implementation that keeps all watched entities in process memory.
+// It is meant for tests and single node deployments; all state is lost on restart.
+type Memory struct {
+	config        configuration.Config
+	mux           sync.Mutex
+	entities      map[key]model.WatchedEntity
+	history       map[key][]model.HistoryEntry
+	triggers      map[string]model.PendingTrigger
+	leaseDuration time.Duration
+	scheduler     *db.Scheduler
+}
+
+type key struct {
+	id     string
+	userId string
+}
+
+func New(config configuration.Config) (*Memory, error) {
+	leaseDuration := time.Minute
+	if config.FetchLeaseDuration != "" {
+		var err error
+		leaseDuration, err = time.ParseDuration(config.FetchLeaseDuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Memory{
+		config:        config,
+		entities:      map[key]model.WatchedEntity{},
+		history:       map[key][]model.HistoryEntry{},
+		triggers:      map[string]model.PendingTrigger{},
+		leaseDuration: leaseDuration,
+		scheduler:     db.NewScheduler(config),
+	}, nil
+}
+
+func (this *Memory) Fetch(owner string, max int64) (result []model.WatchedEntity, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	now := time.Now()
+	candidates := []model.WatchedEntity{}
+	for _, element := range this.entities {
+		if !element.Paused && !element.Disabled && element.TimestampOfNextCheck < now.Unix() && element.LeaseExpiration < now.Unix() {
+			candidates = append(candidates, element)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].TimestampOfNextCheck != candidates[j].TimestampOfNextCheck {
+			return candidates[i].TimestampOfNextCheck < candidates[j].TimestampOfNextCheck
+		}
+		return candidates[i].Id < candidates[j].Id
+	})
+	if max > 0 && int64(len(candidates)) > max {
+		candidates = candidates[:max]
+	}
+	for _, element := range candidates {
+		next, err := this.scheduler.NextCheck(element.WatchedEntityInit, now)
+		if err != nil {
+			this.config.GetLogger().Warn("WARNING: invalid interval or schedule in WatchedEntity --> interpret as 1 hour", "elementId", element.Id, "elementInterval", element.Interval, "elementSchedule", element.Schedule, "error", err)
+		}
+		element.TimestampOfNextCheck = next.Unix()
+		element.LeaseOwner = owner
+		element.LeaseExpiration = now.Add(this.leaseDuration).Unix()
+		this.entities[key{id: element.Id, userId: element.UserId}] = element
+		result = append(result, element)
+	}
+	return result, nil
+}
+
+func (this *Memory) ClaimLease(owner string, id string, userId string) (result model.WatchedEntity, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	now := time.Now()
+	k := key{id: id, userId: userId}
+	result, ok := this.entities[k]
+	if !ok {
+		return result, db.ErrNotFound
+	}
+	if result.LeaseExpiration >= now.Unix() {
+		return result, db.ErrLeased
+	}
+	result.LeaseOwner = owner
+	result.LeaseExpiration = now.Add(this.leaseDuration).Unix()
+	this.entities[k] = result
+	return result, nil
+}
+
+func (this *Memory) ReleaseLease(owner string, id string, userId string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	k := key{id: id, userId: userId}
+	element, ok := this.entities[k]
+	if !ok || element.LeaseOwner != owner {
+		return nil
+	}
+	element.LeaseOwner = ""
+	element.LeaseExpiration = 0
+	this.entities[k] = element
+	return nil
+}
+
+func (this *Memory) UpdateHash(id string, userId string, hash string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	k := key{id: id, userId: userId}
+	element, ok := this.entities[k]
+	if !ok {
+		return nil
+	}
+	element.LastHash = hash
+	this.entities[k] = element
+	return nil
+}
+
+func (this *Memory) SetPendingHash(id string, userId string, hash string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	k := key{id: id, userId: userId}
+	element, ok := this.entities[k]
+	if !ok {
+		return db.ErrNotFound
+	}
+	element.PendingHash = hash
+	this.entities[k] = element
+	return nil
+}
+
+func (this *Memory) PromotePendingHash(id string, userId string, result model.CheckResult, outbox *model.PendingTrigger) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if outbox != nil {
+		this.triggers[outbox.Id] = *outbox
+	}
+	k := key{id: id, userId: userId}
+	element, ok := this.entities[k]
+	if !ok || element.PendingHash != result.Hash {
+		return nil
+	}
+	element.LastHash = result.Hash
+	element.LastDeviceIds = result.DeviceIds
+	element.ETag = result.ETag
+	element.LastModified = result.LastModified
+	element.PendingHash = ""
+	this.entities[k] = element
+	return nil
+}
+
+func (this *Memory) UpdateValidators(id string, userId string, etag string, lastModified string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	k := key{id: id, userId: userId}
+	element, ok := this.entities[k]
+	if !ok {
+		return db.ErrNotFound
+	}
+	element.ETag = etag
+	element.LastModified = lastModified
+	this.entities[k] = element
+	return nil
+}
+
+func (this *Memory) UpdateFailureState(id string, userId string, state model.FailureState, timestampOfNextCheck int64) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	k := key{id: id, userId: userId}
+	element, ok := this.entities[k]
+	if !ok {
+		return db.ErrNotFound
+	}
+	element.FailureState = state
+	element.TimestampOfNextCheck = timestampOfNextCheck
+	this.entities[k] = element
+	return nil
+}
+
+func (this *Memory) SetPaused(id string, userId string, paused bool) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	k := key{id: id, userId: userId}
+	element, ok := this.entities[k]
+	if !ok {
+		return db.ErrNotFound
+	}
+	element.Paused = paused
+	this.entities[k] = element
+	return nil
+}
+
+func (this *Memory) Set(element model.WatchedEntityInit) error {
+	now := time.Now()
+	if element.CreatedAt == 0 {
+		element.CreatedAt = now.Unix()
+	}
+	first, err := this.scheduler.FirstCheck(element, now)
+	if err != nil {
+		this.config.GetLogger().Warn("WARNING: invalid schedule in WatchedEntity --> interpret as 1 hour", "elementId", element.Id, "elementSchedule", element.Schedule, "error", err)
+	}
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.entities[key{id: element.Id, userId: element.UserId}] = model.WatchedEntity{
+		WatchedEntityInit: element,
+		WatchedEntityFetchInfo: model.WatchedEntityFetchInfo{
+			TimestampOfNextCheck: first.Unix(),
+			LastHash:             "",
+		},
+	}
+	return nil
+}
+
+func (this *Memory) Read(id string, userId string) (result model.WatchedEntity, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result, ok := this.entities[key{id: id, userId: userId}]
+	if !ok {
+		return result, db.ErrNotFound
+	}
+	return result, nil
+}
+
+func (this *Memory) ListByUser(userId string, query db.QueryOptions) (result []model.WatchedEntity, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result = []model.WatchedEntity{}
+	for k, entity := range this.entities {
+		if k.userId == userId {
+			result = append(result, entity)
+		}
+	}
+	field, desc := strings.CutSuffix(query.GetSort(), ".desc")
+	field = strings.TrimSuffix(field, ".asc")
+	less := getLessFunc(field)
+	sort.SliceStable(result, func(i, j int) bool {
+		if desc {
+			return less(result[j], result[i])
+		}
+		return less(result[i], result[j])
+	})
+	offset := min(max(query.GetOffset(), 0), int64(len(result)))
+	result = result[offset:]
+	if limit := query.GetLimit(); limit > 0 && limit < int64(len(result)) {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (this *Memory) ListBySourceType(sourceType string) (result []model.WatchedEntity, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result = []model.WatchedEntity{}
+	for _, entity := range this.entities {
+		if entity.SourceType == sourceType {
+			result = append(result, entity)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UserId+"/"+result[i].Id < result[j].UserId+"/"+result[j].Id
+	})
+	return result, nil
+}
+
+func getLessFunc(field string) func(a, b model.WatchedEntity) bool {
+	byId := func(a, b model.WatchedEntity) bool {
+		return a.Id < b.Id
+	}
+	thenById := func(less func(a, b model.WatchedEntity) bool, equal func(a, b model.WatchedEntity) bool) func(a, b model.WatchedEntity) bool {
+		return func(a, b model.WatchedEntity) bool {
+			if equal(a, b) {
+				return byId(a, b)
+			}
+			return less(a, b)
+		}
+	}
+	switch field {
+	case "interval":
+		return thenById(func(a, b model.WatchedEntity) bool { return a.Interval < b.Interval }, func(a, b model.WatchedEntity) bool { return a.Interval == b.Interval })
+	case "hash_type":
+		return thenById(func(a, b model.WatchedEntity) bool { return a.HashType < b.HashType }, func(a, b model.WatchedEntity) bool { return a.HashType == b.HashType })
+	case "created_at":
+		return thenById(func(a, b model.WatchedEntity) bool { return a.CreatedAt < b.CreatedAt }, func(a, b model.WatchedEntity) bool { return a.CreatedAt == b.CreatedAt })
+	case "timestamp_of_next_check":
+		return thenById(func(a, b model.WatchedEntity) bool { return a.TimestampOfNextCheck < b.TimestampOfNextCheck }, func(a, b model.WatchedEntity) bool { return a.TimestampOfNextCheck == b.TimestampOfNextCheck })
+	default:
+		return byId
+	}
+}
+
+func (this *Memory) Delete(id string, userId string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	delete(this.entities, key{id: id, userId: userId})
+	delete(this.history, key{id: id, userId: userId})
+	for triggerId, trigger := range this.triggers {
+		if trigger.WatcherId == id && trigger.UserId == userId {
+			delete(this.triggers, triggerId)
+		}
+	}
+	return nil
+}
+
+func (this *Memory) AddHistoryEntry(entry model.HistoryEntry) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	k := key{id: entry.WatcherId, userId: entry.UserId}
+	list := append(this.history[k], entry)
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Timestamp.Before(list[j].Timestamp)
+	})
+	if retention := this.config.HistoryRetention; retention > 0 && int64(len(list)) > retention {
+		list = append([]model.HistoryEntry{}, list[int64(len(list))-retention:]...)
+	}
+	this.history[k] = list
+	return nil
+}
+
+func (this *Memory) ListHistory(id string, userId string, limit int64) (result []model.HistoryEntry, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	list := this.history[key{id: id, userId: userId}]
+	result = []model.HistoryEntry{}
+	for i := len(list) - 1; i >= 0 && (limit <= 0 || int64(len(result)) < limit); i-- {
+		result = append(result, list[i])
+	}
+	return result, nil
+}
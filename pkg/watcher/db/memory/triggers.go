@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+)
+
+func (this *Memory) AddPendingTrigger(trigger model.PendingTrigger) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.triggers[trigger.Id] = trigger
+	return nil
+}
+
+func (this *Memory) FetchPendingTriggers(max int64) (result []model.PendingTrigger, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	now := time.Now()
+	candidates := []model.PendingTrigger{}
+	for _, trigger := range this.triggers {
+		if !trigger.DeadLetter && trigger.NextAttempt <= now.Unix() {
+			candidates = append(candidates, trigger)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].NextAttempt != candidates[j].NextAttempt {
+			return candidates[i].NextAttempt < candidates[j].NextAttempt
+		}
+		return candidates[i].Id < candidates[j].Id
+	})
+	if max > 0 && int64(len(candidates)) > max {
+		candidates = candidates[:max]
+	}
+	for _, trigger := range candidates {
+		trigger.NextAttempt = now.Add(this.leaseDuration).Unix()
+		this.triggers[trigger.Id] = trigger
+		result = append(result, trigger)
+	}
+	return result, nil
+}
+
+func (this *Memory) UpdatePendingTrigger(trigger model.PendingTrigger) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if _, ok := this.triggers[trigger.Id]; !ok {
+		return db.ErrNotFound
+	}
+	this.triggers[trigger.Id] = trigger
+	return nil
+}
+
+func (this *Memory) RemovePendingTrigger(id string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	delete(this.triggers, id)
+	return nil
+}
+
+func (this *Memory) ReadPendingTrigger(id string, userId string) (result model.PendingTrigger, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result, ok := this.triggers[id]
+	if !ok || result.UserId != userId {
+		return model.PendingTrigger{}, db.ErrNotFound
+	}
+	return result, nil
+}
+
+func (this *Memory) ListDeadLetters(userId string) (result []model.PendingTrigger, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result = []model.PendingTrigger{}
+	for _, trigger := range this.triggers {
+		if trigger.DeadLetter && trigger.UserId == userId {
+			result = append(result, trigger)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].Id < result[j].Id
+	})
+	return result, nil
+}
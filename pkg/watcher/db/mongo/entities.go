@@ -18,9 +18,12 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"runtime/debug"
+	"strings"
 	"time"
 
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -45,6 +48,11 @@ func init() {
 			debug.PrintStack()
 			return err
 		}
+		err = db.ensureIndex(collection, "entity_source_type_index", "source_type", true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
 		return nil
 	})
 }
@@ -53,42 +61,90 @@ func (this *Mongo) entityCollection() *mongo.Collection {
 	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoCollectionWatchedEntity)
 }
 
-func (this *Mongo) Fetch(max int64) (result []model.WatchedEntity, err error) {
+func (this *Mongo) Fetch(owner string, max int64) (result []model.WatchedEntity, err error) {
 	collection := this.entityCollection()
-	opt := options.Find().SetLimit(max)
-	err = this.transaction(func(ctx context.Context) (interface{}, error) {
-		c, err := collection.Find(ctx, bson.M{"timestamp_of_next_check": bson.M{"$lt": time.Now().Unix()}}, opt)
-		if err != nil {
-			return nil, err
-		}
-		result, err = readCursorResult[model.WatchedEntity](ctx, c)
-		if err != nil {
-			return nil, err
-		}
-		for i, element := range result {
-			dur, err := time.ParseDuration(element.Interval)
+	for max <= 0 || int64(len(result)) < max {
+		claimed := false
+		element := model.WatchedEntity{}
+		err = this.transaction(func(ctx context.Context) (interface{}, error) {
+			claimed = false
+			element = model.WatchedEntity{}
+			now := time.Now()
+			err := collection.FindOneAndUpdate(ctx, bson.M{
+				"timestamp_of_next_check": bson.M{"$lt": now.Unix()},
+				"lease_expiration":        bson.M{"$not": bson.M{"$gte": now.Unix()}},
+				"paused":                  bson.M{"$ne": true},
+				"disabled":                bson.M{"$ne": true},
+			}, bson.M{
+				"$set": bson.M{"lease_owner": owner, "lease_expiration": now.Add(this.leaseDuration).Unix()},
+			}, options.FindOneAndUpdate().SetSort(bson.D{{Key: "timestamp_of_next_check", Value: 1}}).SetReturnDocument(options.After)).Decode(&element)
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, nil
+			}
 			if err != nil {
-				this.config.GetLogger().Warn("WARNING: invalid interval in WatchedEntity --> interpret interval as 1 hour", "elementId", element.Id, "elementInterval", element.Interval, "error", err)
-				dur = time.Hour
-				err = nil
+				return nil, err
 			}
-			element.TimestampOfNextCheck = time.Now().Add(dur).Unix()
-			result[i] = element
+			next, err := this.scheduler.NextCheck(element.WatchedEntityInit, now)
+			if err != nil {
+				this.config.GetLogger().Warn("WARNING: invalid interval or schedule in WatchedEntity --> interpret as 1 hour", "elementId", element.Id, "elementInterval", element.Interval, "elementSchedule", element.Schedule, "error", err)
+			}
+			element.TimestampOfNextCheck = next.Unix()
 			_, err = collection.UpdateOne(ctx, bson.M{
 				WatchedEntityBson.Id:     element.Id,
 				WatchedEntityBson.UserId: element.UserId,
+				"lease_owner":            owner,
 			}, bson.M{
 				"$set": bson.M{"timestamp_of_next_check": element.TimestampOfNextCheck},
 			})
 			if err != nil {
 				return nil, err
 			}
+			claimed = true
+			return nil, nil
+		})
+		if err != nil || !claimed {
+			return result, err
 		}
-		return nil, nil
-	})
+		result = append(result, element)
+	}
+	return result, nil
+}
+
+func (this *Mongo) ClaimLease(owner string, id string, userId string) (result model.WatchedEntity, err error) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	now := time.Now()
+	err = this.entityCollection().FindOneAndUpdate(ctx, bson.M{
+		WatchedEntityBson.Id:     id,
+		WatchedEntityBson.UserId: userId,
+		"lease_expiration":       bson.M{"$not": bson.M{"$gte": now.Unix()}},
+	}, bson.M{
+		"$set": bson.M{"lease_owner": owner, "lease_expiration": now.Add(this.leaseDuration).Unix()},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		//unknown or leased entity
+		_, err = this.Read(id, userId)
+		if err != nil {
+			return result, err
+		}
+		return result, db.ErrLeased
+	}
 	return result, err
 }
 
+func (this *Mongo) ReleaseLease(owner string, id string, userId string) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	_, err := this.entityCollection().UpdateOne(ctx, bson.M{
+		WatchedEntityBson.Id:     id,
+		WatchedEntityBson.UserId: userId,
+		"lease_owner":            owner,
+	}, bson.M{
+		"$set": bson.M{"lease_owner": "", "lease_expiration": 0},
+	})
+	return err
+}
+
 func (this *Mongo) transaction(f func(ctx context.Context) (interface{}, error)) error {
 	if this.config.MongoUseRelSet {
 		wc := writeconcern.New(writeconcern.WMajority())
@@ -123,12 +179,119 @@ func (this *Mongo) UpdateHash(id string, userId string, hash string) error {
 	return err
 }
 
+func (this *Mongo) SetPendingHash(id string, userId string, hash string) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	result, err := this.entityCollection().UpdateOne(ctx, bson.M{
+		WatchedEntityBson.Id:     id,
+		WatchedEntityBson.UserId: userId,
+	}, bson.M{
+		"$set": bson.M{"pending_hash": hash},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (this *Mongo) PromotePendingHash(id string, userId string, result model.CheckResult, outbox *model.PendingTrigger) error {
+	return this.transaction(func(ctx context.Context) (interface{}, error) {
+		if outbox != nil {
+			_, err := this.triggerCollection().InsertOne(ctx, outbox)
+			if err != nil {
+				return nil, err
+			}
+		}
+		_, err := this.entityCollection().UpdateOne(ctx, bson.M{
+			WatchedEntityBson.Id:     id,
+			WatchedEntityBson.UserId: userId,
+			"pending_hash":           result.Hash,
+		}, bson.M{
+			"$set": bson.M{
+				WatchedEntityBson.LastHash: result.Hash,
+				"last_device_ids":          result.DeviceIds,
+				"etag":                     result.ETag,
+				"last_modified":            result.LastModified,
+				"pending_hash":             "",
+			},
+		})
+		return nil, err
+	})
+}
+
+func (this *Mongo) UpdateValidators(id string, userId string, etag string, lastModified string) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	result, err := this.entityCollection().UpdateOne(ctx, bson.M{
+		WatchedEntityBson.Id:     id,
+		WatchedEntityBson.UserId: userId,
+	}, bson.M{
+		"$set": bson.M{"etag": etag, "last_modified": lastModified},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (this *Mongo) UpdateFailureState(id string, userId string, state model.FailureState, timestampOfNextCheck int64) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	result, err := this.entityCollection().UpdateOne(ctx, bson.M{
+		WatchedEntityBson.Id:     id,
+		WatchedEntityBson.UserId: userId,
+	}, bson.M{
+		"$set": bson.M{
+			"consecutive_failures":    state.ConsecutiveFailures,
+			"last_error":              state.LastError,
+			"disabled":                state.Disabled,
+			"timestamp_of_next_check": timestampOfNextCheck,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (this *Mongo) SetPaused(id string, userId string, paused bool) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	result, err := this.entityCollection().UpdateOne(ctx, bson.M{
+		WatchedEntityBson.Id:     id,
+		WatchedEntityBson.UserId: userId,
+	}, bson.M{
+		"$set": bson.M{"paused": paused},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
 func (this *Mongo) Set(element model.WatchedEntityInit) error {
+	now := time.Now()
 	if element.CreatedAt == 0 {
-		element.CreatedAt = time.Now().Unix()
+		element.CreatedAt = now.Unix()
+	}
+	first, err := this.scheduler.FirstCheck(element, now)
+	if err != nil {
+		this.config.GetLogger().Warn("WARNING: invalid schedule in WatchedEntity --> interpret as 1 hour", "elementId", element.Id, "elementSchedule", element.Schedule, "error", err)
 	}
 	ctx, _ := getTimeoutContext()
-	_, err := this.entityCollection().ReplaceOne(
+	_, err = this.entityCollection().ReplaceOne(
 		ctx,
 		bson.M{
 			WatchedEntityBson.Id:     element.Id,
@@ -137,7 +300,7 @@ func (this *Mongo) Set(element model.WatchedEntityInit) error {
 		model.WatchedEntity{
 			WatchedEntityInit: element,
 			WatchedEntityFetchInfo: model.WatchedEntityFetchInfo{
-				TimestampOfNextCheck: 0,
+				TimestampOfNextCheck: first.Unix(),
 				LastHash:             "",
 			},
 		},
@@ -149,6 +312,9 @@ func (this *Mongo) Read(id string, userId string) (result model.WatchedEntity, e
 	ctx, _ := getTimeoutContext()
 	temp := this.entityCollection().FindOne(ctx, bson.M{WatchedEntityBson.Id: id, WatchedEntityBson.UserId: userId})
 	err = temp.Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return result, db.ErrNotFound
+	}
 	if err != nil {
 		return result, err
 	}
@@ -162,7 +328,14 @@ func (this *Mongo) Delete(id string, userId string) error {
 		WatchedEntityBson.Id:     id,
 		WatchedEntityBson.UserId: userId,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	err = this.deleteHistory(id, userId)
+	if err != nil {
+		return err
+	}
+	return this.deletePendingTriggers(id, userId)
 }
 
 func (this *Mongo) List(filter bson.M, query QueryOptions) (result []model.WatchedEntity, err error) {
@@ -177,3 +350,51 @@ func (this *Mongo) List(filter bson.M, query QueryOptions) (result []model.Watch
 	}
 	return readCursorResult[model.WatchedEntity](ctx, cursor)
 }
+
+var sortFieldToBson = map[string]string{
+	"id":                      WatchedEntityBson.Id,
+	"interval":                WatchedEntityBson.Interval,
+	"hash_type":               WatchedEntityBson.HashType,
+	"created_at":              "createdat",
+	"timestamp_of_next_check": "timestamp_of_next_check",
+}
+
+func (this *Mongo) ListByUser(userId string, query QueryOptions) (result []model.WatchedEntity, err error) {
+	sort := query.GetSort()
+	direction := ".asc"
+	if strings.HasSuffix(sort, ".desc") {
+		direction = ".desc"
+	}
+	field, ok := sortFieldToBson[strings.TrimSuffix(strings.TrimSuffix(sort, ".asc"), ".desc")]
+	if !ok {
+		field = WatchedEntityBson.Id
+	}
+	result, err = this.List(bson.M{WatchedEntityBson.UserId: userId}, queryWithSort{QueryOptions: query, sort: field + direction})
+	if result == nil {
+		result = []model.WatchedEntity{}
+	}
+	return result, err
+}
+
+func (this *Mongo) ListBySourceType(sourceType string) (result []model.WatchedEntity, err error) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	cursor, err := this.entityCollection().Find(ctx, bson.M{"source_type": sourceType})
+	if err != nil {
+		return result, err
+	}
+	result, err = readCursorResult[model.WatchedEntity](ctx, cursor)
+	if result == nil {
+		result = []model.WatchedEntity{}
+	}
+	return result, err
+}
+
+type queryWithSort struct {
+	QueryOptions
+	sort string
+}
+
+func (this queryWithSort) GetSort() string {
+	return this.sort
+}
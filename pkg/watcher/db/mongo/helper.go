@@ -18,17 +18,14 @@ package mongo
 
 import (
 	"context"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"strings"
 )
 
-type QueryOptions interface {
-	GetLimit() int64
-	GetOffset() int64
-	GetSort() string
-}
+type QueryOptions = db.QueryOptions
 
 func createFindOptions(query QueryOptions) *options.FindOptions {
 	opt := options.Find()
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"errors"
+	"runtime/debug"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var HistoryEntryBson = getBsonFieldObject[model.HistoryEntry]()
+
+func init() {
+	CreateCollections = append(CreateCollections, func(db *Mongo) error {
+		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoCollectionHistory)
+		err := db.ensureCompoundIndex(collection, "history_watcher_user_timestamp_index", true, false, HistoryEntryBson.WatcherId, HistoryEntryBson.UserId, "timestamp")
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) historyCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoCollectionHistory)
+}
+
+func (this *Mongo) AddHistoryEntry(entry model.HistoryEntry) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	collection := this.historyCollection()
+	_, err := collection.InsertOne(ctx, entry)
+	if err != nil {
+		return err
+	}
+	if this.config.HistoryRetention <= 0 {
+		return nil
+	}
+	//find the newest entry that exceeds the retention limit and remove it together with all older entries
+	oldest := model.HistoryEntry{}
+	err = collection.FindOne(ctx, bson.M{
+		HistoryEntryBson.WatcherId: entry.WatcherId,
+		HistoryEntryBson.UserId:    entry.UserId,
+	}, options.FindOne().SetSort(bson.D{{Key: "timestamp", Value: -1}}).SetSkip(this.config.HistoryRetention)).Decode(&oldest)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = collection.DeleteMany(ctx, bson.M{
+		HistoryEntryBson.WatcherId: entry.WatcherId,
+		HistoryEntryBson.UserId:    entry.UserId,
+		"timestamp":                bson.M{"$lte": oldest.Timestamp},
+	})
+	return err
+}
+
+func (this *Mongo) ListHistory(id string, userId string, limit int64) (result []model.HistoryEntry, err error) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	opt := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+	if limit > 0 {
+		opt.SetLimit(limit)
+	}
+	cursor, err := this.historyCollection().Find(ctx, bson.M{
+		HistoryEntryBson.WatcherId: id,
+		HistoryEntryBson.UserId:    userId,
+	}, opt)
+	if err != nil {
+		return result, err
+	}
+	result, err = readCursorResult[model.HistoryEntry](ctx, cursor)
+	if result == nil {
+		result = []model.HistoryEntry{}
+	}
+	return result, err
+}
+
+func (this *Mongo) deleteHistory(id string, userId string) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	_, err := this.historyCollection().DeleteMany(ctx, bson.M{
+		HistoryEntryBson.WatcherId: id,
+		HistoryEntryBson.UserId:    userId,
+	})
+	return err
+}
@@ -19,6 +19,7 @@ package mongo
 import (
 	"context"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -28,20 +29,31 @@ import (
 )
 
 type Mongo struct {
-	config configuration.Config
-	client *mongo.Client
+	config        configuration.Config
+	client        *mongo.Client
+	leaseDuration time.Duration
+	scheduler     *db.Scheduler
 }
 
 var CreateCollections = []func(db *Mongo) error{}
 
 func New(conf configuration.Config, ctx context.Context) (*Mongo, error) {
+	leaseDuration := time.Minute
+	if conf.FetchLeaseDuration != "" {
+		var err error
+		leaseDuration, err = time.ParseDuration(conf.FetchLeaseDuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+	scheduler := db.NewScheduler(conf)
 	timeout, _ := getTimeoutContext()
 	reg := bson.NewRegistryBuilder().RegisterTypeMapEntry(bsontype.EmbeddedDocument, reflect.TypeOf(bson.M{})).Build() //ensure map marshalling to interface
 	client, err := mongo.Connect(timeout, options.Client().ApplyURI(conf.MongoUrl), options.Client().SetRegistry(reg))
 	if err != nil {
 		return nil, err
 	}
-	db := &Mongo{config: conf, client: client}
+	db := &Mongo{config: conf, client: client, leaseDuration: leaseDuration, scheduler: scheduler}
 	for _, creators := range CreateCollections {
 		err = creators(db)
 		if err != nil {
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"errors"
+	"runtime/debug"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var PendingTriggerBson = getBsonFieldObject[model.PendingTrigger]()
+
+func init() {
+	CreateCollections = append(CreateCollections, func(db *Mongo) error {
+		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoCollectionTriggers)
+		err := db.ensureIndex(collection, "trigger_id_index", PendingTriggerBson.Id, true, true)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "trigger_next_attempt_index", "next_attempt", true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureCompoundIndex(collection, "trigger_watcher_user_index", true, false, PendingTriggerBson.WatcherId, PendingTriggerBson.UserId)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) triggerCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoCollectionTriggers)
+}
+
+func (this *Mongo) AddPendingTrigger(trigger model.PendingTrigger) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	_, err := this.triggerCollection().InsertOne(ctx, trigger)
+	return err
+}
+
+func (this *Mongo) FetchPendingTriggers(max int64) (result []model.PendingTrigger, err error) {
+	collection := this.triggerCollection()
+	for max <= 0 || int64(len(result)) < max {
+		ctx, cancel := getTimeoutContext()
+		now := time.Now()
+		element := model.PendingTrigger{}
+		err = collection.FindOneAndUpdate(ctx, bson.M{
+			"dead_letter":  bson.M{"$ne": true},
+			"next_attempt": bson.M{"$lte": now.Unix()},
+		}, bson.M{
+			"$set": bson.M{"next_attempt": now.Add(this.leaseDuration).Unix()},
+		}, options.FindOneAndUpdate().SetSort(bson.D{{Key: "next_attempt", Value: 1}}).SetReturnDocument(options.After)).Decode(&element)
+		cancel()
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, element)
+	}
+	return result, nil
+}
+
+func (this *Mongo) UpdatePendingTrigger(trigger model.PendingTrigger) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	result, err := this.triggerCollection().ReplaceOne(ctx, bson.M{PendingTriggerBson.Id: trigger.Id}, trigger)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+func (this *Mongo) RemovePendingTrigger(id string) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	_, err := this.triggerCollection().DeleteOne(ctx, bson.M{PendingTriggerBson.Id: id})
+	return err
+}
+
+func (this *Mongo) ReadPendingTrigger(id string, userId string) (result model.PendingTrigger, err error) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	err = this.triggerCollection().FindOne(ctx, bson.M{
+		PendingTriggerBson.Id:     id,
+		PendingTriggerBson.UserId: userId,
+	}).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return result, db.ErrNotFound
+	}
+	return result, err
+}
+
+func (this *Mongo) ListDeadLetters(userId string) (result []model.PendingTrigger, err error) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	cursor, err := this.triggerCollection().Find(ctx, bson.M{
+		PendingTriggerBson.UserId: userId,
+		"dead_letter":             true,
+	}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: PendingTriggerBson.Id, Value: 1}}))
+	if err != nil {
+		return result, err
+	}
+	result, err = readCursorResult[model.PendingTrigger](ctx, cursor)
+	if result == nil {
+		result = []model.PendingTrigger{}
+	}
+	return result, err
+}
+
+func (this *Mongo) deletePendingTriggers(watcherId string, userId string) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	_, err := this.triggerCollection().DeleteMany(ctx, bson.M{
+		PendingTriggerBson.WatcherId: watcherId,
+		PendingTriggerBson.UserId:    userId,
+	})
+	return err
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math/rand/v2"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/schedule"
+)
+
+// fallbackInterval is used for entities with invalid Interval or Schedule
+const fallbackInterval = time.Hour
+
+var errUnreachableSchedule = errors.New("schedule has no reachable date")
+
+// Scheduler computes the timestamps of the next checks of entities, delayed by the configured jitter
+// to spread entities created together, e.g. by one bulk deployment.
+// the jitter is derived from the seed, the entity and the undelayed time of the check,
+// so that equal seeds and clocks result in equal timestamps.
+type Scheduler struct {
+	initialJitter time.Duration
+	jitter        time.Duration
+	seed          uint64
+}
+
+// NewScheduler uses config.CheckJitterSeed or a random seed if none is set
+func NewScheduler(config configuration.Config) *Scheduler {
+	seed := uint64(config.CheckJitterSeed)
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &Scheduler{
+		initialJitter: config.ParseDuration("check_initial_jitter", config.CheckInitialJitter, 0),
+		jitter:        config.ParseDuration("check_jitter", config.CheckJitter, 0),
+		seed:          seed,
+	}
+}
+
+// NextCheck returns the time of the next regular check of the entity after now, delayed by up to check_jitter.
+// entities with a Schedule are checked at the next scheduled minute, others after their Interval.
+// on error the undelayed time is now + 1h.
+func (this *Scheduler) NextCheck(entity model.WatchedEntityInit, now time.Time) (time.Time, error) {
+	base, err := nextCheck(entity, now)
+	return base.Add(this.offset(entity, base, this.jitter)), err
+}
+
+// FirstCheck returns the time of the first check of a new entity, delayed by up to check_initial_jitter.
+// entities with a Schedule wait for the next scheduled minute, others are checked immediately.
+func (this *Scheduler) FirstCheck(entity model.WatchedEntityInit, now time.Time) (time.Time, error) {
+	if entity.Schedule == "" && this.initialJitter <= 0 {
+		return time.Unix(0, 0), nil
+	}
+	base, err := now, error(nil)
+	if entity.Schedule != "" {
+		base, err = nextCheck(entity, now)
+	}
+	return base.Add(this.offset(entity, base, this.initialJitter)), err
+}
+
+// offset returns a pseudo random duration in [0, max)
+func (this *Scheduler) offset(entity model.WatchedEntityInit, base time.Time, max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(entity.UserId))
+	h.Write([]byte{0})
+	h.Write([]byte(entity.Id))
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(base.Unix())))
+	return time.Duration(rand.New(rand.NewPCG(this.seed, h.Sum64())).Int64N(int64(max)))
+}
+
+func nextCheck(entity model.WatchedEntityInit, now time.Time) (time.Time, error) {
+	if entity.Schedule != "" {
+		s, err := schedule.Parse(entity.Schedule)
+		if err != nil {
+			return now.Add(fallbackInterval), err
+		}
+		next := s.Next(now)
+		if next.IsZero() {
+			return now.Add(fallbackInterval), errUnreachableSchedule
+		}
+		return next, nil
+	}
+	dur, err := time.ParseDuration(entity.Interval)
+	if err != nil {
+		return now.Add(fallbackInterval), err
+	}
+	return now.Add(dur), nil
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/jsonpath"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+)
+
+const TRIGGER_CONDITION_ADDED_ONLY = "added_only"
+const TRIGGER_CONDITION_REMOVED_ONLY = "removed_only"
+
+// TriggerConditions lists the valid values of model.WatchedEntityInit.TriggerCondition; the empty condition triggers on every change
+var TriggerConditions = []string{"", TRIGGER_CONDITION_ADDED_ONLY, TRIGGER_CONDITION_REMOVED_ONLY}
+
+const TRIGGER_INPUT_ADDED_DEVICES = "added_devices"
+const TRIGGER_INPUT_REMOVED_DEVICES = "removed_devices"
+const TRIGGER_INPUT_OLD_HASH = "old_hash"
+const TRIGGER_INPUT_NEW_HASH = "new_hash"
+const TRIGGER_INPUT_CHECK_TIMESTAMP = "check_timestamp"
+const TRIGGER_INPUT_RESPONSE = "response"
+
+// TriggerInputNames lists the valid keys of model.WatchedEntityInit.TriggerInputs
+var TriggerInputNames = []string{
+	TRIGGER_INPUT_ADDED_DEVICES,
+	TRIGGER_INPUT_REMOVED_DEVICES,
+	TRIGGER_INPUT_OLD_HASH,
+	TRIGGER_INPUT_NEW_HASH,
+	TRIGGER_INPUT_CHECK_TIMESTAMP,
+	TRIGGER_INPUT_RESPONSE,
+}
+
+// matchesTriggerCondition decides if a detected change runs the trigger of the entity.
+// a predicate that became false never triggers. the device conditions require the device diff of the deviceids hash type.
+func matchesTriggerCondition(entity model.WatchedEntity, result model.CheckResult) bool {
+	if result.Predicate != nil && !*result.Predicate {
+		return false
+	}
+	switch entity.TriggerCondition {
+	case TRIGGER_CONDITION_ADDED_ONLY:
+		return result.DeviceDiff != nil && len(result.DeviceDiff.Added) > 0
+	case TRIGGER_CONDITION_REMOVED_ONLY:
+		return result.DeviceDiff != nil && len(result.DeviceDiff.Removed) > 0
+	default:
+		return true
+	}
+}
+
+// getTriggerInputValue returns the value of a model.WatchedEntityInit.TriggerInputs key.
+// ok is false if the value is not available, e.g. the device diff of hash types other than deviceids.
+func getTriggerInputValue(name string, entity model.WatchedEntity, result model.CheckResult, checkTime time.Time) (value interface{}, ok bool, err error) {
+	switch name {
+	case TRIGGER_INPUT_ADDED_DEVICES:
+		if result.DeviceDiff == nil {
+			return nil, false, nil
+		}
+		return result.DeviceDiff.Added, true, nil
+	case TRIGGER_INPUT_REMOVED_DEVICES:
+		if result.DeviceDiff == nil {
+			return nil, false, nil
+		}
+		return result.DeviceDiff.Removed, true, nil
+	case TRIGGER_INPUT_OLD_HASH:
+		return entity.LastHash, true, nil
+	case TRIGGER_INPUT_NEW_HASH:
+		return result.Hash, true, nil
+	case TRIGGER_INPUT_CHECK_TIMESTAMP:
+		return checkTime.UTC().Format(time.RFC3339), true, nil
+	case TRIGGER_INPUT_RESPONSE:
+		return getResponseInput(entity.TriggerResponsePath, result.Payload)
+	default:
+		return nil, false, nil
+	}
+}
+
+// getResponseInput returns the watched response as parsed json or, if it is no json, as string.
+// with a json path only the selected values are returned: a single match as value, otherwise as list.
+// ok is false if a json path is set but the response is no json, e.g. an html error page with an accepted status code.
+func getResponseInput(path string, payload []byte) (result interface{}, ok bool, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	err = decoder.Decode(&result)
+	if err != nil {
+		if path != "" {
+			return nil, false, nil
+		}
+		return string(payload), true, nil
+	}
+	if path == "" {
+		return result, true, nil
+	}
+	selected, err := jsonpath.Select(path, result)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(selected) == 1 {
+		return selected[0], true, nil
+	}
+	return selected, true, nil
+}
+
+// renderTrigger adds the values selected by entity.TriggerInputs as maintenance procedure inputs to the trigger body.
+// the body is expected to be a json list of smart service parameters; inputs with the same id are replaced.
+func renderTrigger(entity model.WatchedEntity, result model.CheckResult, checkTime time.Time) (trigger model.HttpRequest, err error) {
+	trigger = entity.Trigger
+	if len(entity.TriggerInputs) == 0 {
+		return trigger, nil
+	}
+	parameters := []map[string]interface{}{}
+	if len(trigger.Body) > 0 {
+		err = json.Unmarshal(trigger.Body, &parameters)
+		if err != nil {
+			return trigger, fmt.Errorf("unable to add trigger inputs: expect trigger body as list of parameters: %w", err)
+		}
+	}
+	names := make([]string, 0, len(entity.TriggerInputs))
+	for name := range entity.TriggerInputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, ok, err := getTriggerInputValue(name, entity, result, checkTime)
+		if err != nil {
+			return trigger, fmt.Errorf("unable to render trigger input %v: %w", name, err)
+		}
+		if !ok {
+			continue
+		}
+		parameters = setParameter(parameters, entity.TriggerInputs[name], value)
+	}
+	trigger.Body, err = json.Marshal(parameters)
+	return trigger, err
+}
+
+func setParameter(parameters []map[string]interface{}, id string, value interface{}) []map[string]interface{} {
+	for _, parameter := range parameters {
+		if parameter["id"] == id {
+			parameter["value"] = value
+			delete(parameter, "value_label")
+			return parameters
+		}
+	}
+	return append(parameters, map[string]interface{}{"id": id, "label": id, "value": value})
+}
@@ -0,0 +1,380 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package jsonpath implements the JSONPath subset used to select parts of watched responses:
+// the root $, child names (.name, ['name']), wildcards (.*, [*]), indexes ([0], [-1]),
+// slices ([1:3]), unions ([0,2], ['a','b']) and recursive descent (..name, ..*).
+// Filter and script expressions are not supported.
+package jsonpath
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidPath = errors.New("invalid json path")
+
+type Path struct {
+	expression string
+	segments   []segment
+}
+
+type segment struct {
+	recursive bool
+	selectors []selector
+}
+
+type selector interface {
+	apply(value interface{}, result []interface{}) []interface{}
+}
+
+// Parse parses a complete JSONPath expression
+func Parse(expression string) (result Path, err error) {
+	result, rest, err := ParsePrefix(expression)
+	if err != nil {
+		return result, err
+	}
+	if rest != "" {
+		return result, fmt.Errorf("%w: unexpected %#v in %#v", ErrInvalidPath, rest, expression)
+	}
+	return result, nil
+}
+
+// ParsePrefix parses the JSONPath expression at the start of input and returns the remaining input
+func ParsePrefix(input string) (result Path, rest string, err error) {
+	p := &parser{input: input}
+	result.segments, err = p.parse()
+	if err != nil {
+		return result, input, err
+	}
+	result.expression = input[:p.pos]
+	return result, input[p.pos:], nil
+}
+
+// Select returns all values of doc matched by the expression.
+// doc is expected to be the result of json.Unmarshal into an interface{}.
+func Select(expression string, doc interface{}) ([]interface{}, error) {
+	path, err := Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	return path.Select(doc), nil
+}
+
+func (this Path) String() string {
+	return this.expression
+}
+
+// Select returns all values of doc matched by the path in document order; object members are visited in key order
+func (this Path) Select(doc interface{}) []interface{} {
+	current := []interface{}{doc}
+	for _, seg := range this.segments {
+		next := []interface{}{}
+		for _, value := range current {
+			candidates := []interface{}{value}
+			if seg.recursive {
+				candidates = descendants(value, []interface{}{})
+			}
+			for _, candidate := range candidates {
+				for _, sel := range seg.selectors {
+					next = sel.apply(candidate, next)
+				}
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+func descendants(value interface{}, result []interface{}) []interface{} {
+	result = append(result, value)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			result = descendants(v[key], result)
+		}
+	case []interface{}:
+		for _, element := range v {
+			result = descendants(element, result)
+		}
+	}
+	return result
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type nameSelector string
+
+func (this nameSelector) apply(value interface{}, result []interface{}) []interface{} {
+	if m, ok := value.(map[string]interface{}); ok {
+		if child, ok := m[string(this)]; ok {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+type wildcardSelector struct{}
+
+func (this wildcardSelector) apply(value interface{}, result []interface{}) []interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			result = append(result, v[key])
+		}
+	case []interface{}:
+		result = append(result, v...)
+	}
+	return result
+}
+
+type indexSelector int
+
+func (this indexSelector) apply(value interface{}, result []interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		index := int(this)
+		if index < 0 {
+			index = len(list) + index
+		}
+		if index >= 0 && index < len(list) {
+			result = append(result, list[index])
+		}
+	}
+	return result
+}
+
+type sliceSelector struct {
+	start *int
+	end   *int
+}
+
+func (this sliceSelector) apply(value interface{}, result []interface{}) []interface{} {
+	list, ok := value.([]interface{})
+	if !ok {
+		return result
+	}
+	normalize := func(index *int, defaultValue int) int {
+		if index == nil {
+			return defaultValue
+		}
+		result := *index
+		if result < 0 {
+			result = len(list) + result
+		}
+		return min(max(result, 0), len(list))
+	}
+	start := normalize(this.start, 0)
+	end := normalize(this.end, len(list))
+	if start < end {
+		result = append(result, list[start:end]...)
+	}
+	return result
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (this *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %v at position %v in %#v", ErrInvalidPath, fmt.Sprintf(format, args...), this.pos, this.input)
+}
+
+func (this *parser) peek() byte {
+	if this.pos < len(this.input) {
+		return this.input[this.pos]
+	}
+	return 0
+}
+
+func (this *parser) parse() (result []segment, err error) {
+	if this.peek() != '$' {
+		return nil, this.errorf("expected $")
+	}
+	this.pos++
+	for {
+		switch this.peek() {
+		case '.':
+			this.pos++
+			recursive := false
+			if this.peek() == '.' {
+				this.pos++
+				recursive = true
+			}
+			if this.peek() == '[' {
+				if !recursive {
+					return nil, this.errorf("unexpected [ after .")
+				}
+				selectors, err := this.parseBracket()
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, segment{recursive: true, selectors: selectors})
+				continue
+			}
+			sel, err := this.parseDotSelector()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, segment{recursive: recursive, selectors: []selector{sel}})
+		case '[':
+			selectors, err := this.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, segment{selectors: selectors})
+		default:
+			return result, nil
+		}
+	}
+}
+
+func (this *parser) parseDotSelector() (selector, error) {
+	if this.peek() == '*' {
+		this.pos++
+		return wildcardSelector{}, nil
+	}
+	start := this.pos
+	for this.pos < len(this.input) && isNameChar(this.input[this.pos]) {
+		this.pos++
+	}
+	if start == this.pos {
+		return nil, this.errorf("expected member name")
+	}
+	return nameSelector(this.input[start:this.pos]), nil
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c == '-' || c == '$' || c == '@' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c >= 0x80
+}
+
+func (this *parser) skipSpaces() {
+	for this.peek() == ' ' {
+		this.pos++
+	}
+}
+
+func (this *parser) parseBracket() (result []selector, err error) {
+	this.pos++ // [
+	for {
+		this.skipSpaces()
+		sel, err := this.parseBracketSelector()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, sel)
+		this.skipSpaces()
+		switch this.peek() {
+		case ',':
+			this.pos++
+		case ']':
+			this.pos++
+			return result, nil
+		default:
+			return nil, this.errorf("expected , or ]")
+		}
+	}
+}
+
+func (this *parser) parseBracketSelector() (selector, error) {
+	switch c := this.peek(); {
+	case c == '*':
+		this.pos++
+		return wildcardSelector{}, nil
+	case c == '\'' || c == '"':
+		name, err := this.parseQuoted(c)
+		if err != nil {
+			return nil, err
+		}
+		return nameSelector(name), nil
+	case c == '-' || c == ':' || (c >= '0' && c <= '9'):
+		start, hasStart, err := this.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		this.skipSpaces()
+		if this.peek() != ':' {
+			if !hasStart {
+				return nil, this.errorf("expected index")
+			}
+			return indexSelector(start), nil
+		}
+		this.pos++ // :
+		this.skipSpaces()
+		end, hasEnd, err := this.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		result := sliceSelector{}
+		if hasStart {
+			result.start = &start
+		}
+		if hasEnd {
+			result.end = &end
+		}
+		return result, nil
+	default:
+		return nil, this.errorf("unexpected selector")
+	}
+}
+
+func (this *parser) parseInt() (result int, ok bool, err error) {
+	start := this.pos
+	if this.peek() == '-' {
+		this.pos++
+	}
+	for this.peek() >= '0' && this.peek() <= '9' {
+		this.pos++
+	}
+	if start == this.pos {
+		return 0, false, nil
+	}
+	result, err = strconv.Atoi(this.input[start:this.pos])
+	if err != nil {
+		return 0, false, this.errorf("invalid integer %#v", this.input[start:this.pos])
+	}
+	return result, true, nil
+}
+
+func (this *parser) parseQuoted(quote byte) (string, error) {
+	this.pos++ // opening quote
+	builder := strings.Builder{}
+	for this.pos < len(this.input) {
+		c := this.input[this.pos]
+		switch {
+		case c == quote:
+			this.pos++
+			return builder.String(), nil
+		case c == '\\' && this.pos+1 < len(this.input):
+			builder.WriteByte(this.input[this.pos+1])
+			this.pos += 2
+		default:
+			builder.WriteByte(c)
+			this.pos++
+		}
+	}
+	return "", this.errorf("unterminated string")
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const testDocument = `{
+	"store": {
+		"book": [
+			{"title": "a", "price": 8},
+			{"title": "b", "price": 12},
+			{"title": "c", "price": 9, "isbn": "123"}
+		],
+		"bicycle": {"color": "red", "price": 20}
+	},
+	"odd key": true,
+	"updated_at": "2026-01-01"
+}`
+
+func TestSelect(t *testing.T) {
+	var doc interface{}
+	err := json.Unmarshal([]byte(testDocument), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path     string
+		expected []interface{}
+	}{
+		{path: "$", expected: []interface{}{doc}},
+		{path: "$.store.bicycle.color", expected: []interface{}{"red"}},
+		{path: "$['store']['bicycle']['color']", expected: []interface{}{"red"}},
+		{path: `$["odd key"]`, expected: []interface{}{true}},
+		{path: "$.store.book[0].title", expected: []interface{}{"a"}},
+		{path: "$.store.book[-1].title", expected: []interface{}{"c"}},
+		{path: "$.store.book[5].title", expected: []interface{}{}},
+		{path: "$.store.book[*].title", expected: []interface{}{"a", "b", "c"}},
+		{path: "$.store.book.*.price", expected: []interface{}{8.0, 12.0, 9.0}},
+		{path: "$.store.book[0,2].title", expected: []interface{}{"a", "c"}},
+		{path: "$.store.book[1:].title", expected: []interface{}{"b", "c"}},
+		{path: "$.store.book[:-1].title", expected: []interface{}{"a", "b"}},
+		{path: "$.store.bicycle['color','price']", expected: []interface{}{"red", 20.0}},
+		{path: "$..price", expected: []interface{}{20.0, 8.0, 12.0, 9.0}},
+		{path: "$..book[1].title", expected: []interface{}{"b"}},
+		{path: "$..isbn", expected: []interface{}{"123"}},
+		{path: "$.store.*.color", expected: []interface{}{"red"}},
+		{path: "$.missing.path", expected: []interface{}{}},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			result, err := Select(test.path, doc)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("%#v", result)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, path := range []string{"", "store", "$.", "$[", "$[foo]", "$['unterminated", "$.a b", "$[1,", "$.[0]"} {
+		t.Run(path, func(t *testing.T) {
+			_, err := Parse(path)
+			if !errors.Is(err, ErrInvalidPath) {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	path, rest, err := ParsePrefix("$.battery.level < 20")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if path.String() != "$.battery.level" || rest != " < 20" {
+		t.Error(path.String(), rest)
+	}
+}
@@ -17,27 +17,65 @@
 package model
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
+	"time"
 )
 
+const SOURCE_TYPE_HTTP = "http"
+
 type WatchedEntity struct {
 	WatchedEntityInit      `bson:",inline"`
 	WatchedEntityFetchInfo `bson:",inline"`
 }
 
 type WatchedEntityInit struct {
-	Id        string      `json:"id"`
-	UserId    string      `json:"user_id"`
-	Interval  string      `json:"interval"`
-	HashType  string      `json:"hash_type"`
-	Watch     HttpRequest `json:"watch"`
-	Trigger   HttpRequest `json:"trigger"`
-	CreatedAt int64       `json:"created_at"`
+	Id                  string            `json:"id"`
+	UserId              string            `json:"user_id"`
+	Interval            string            `json:"interval"`
+	Schedule            string            `json:"schedule" bson:"schedule"` //cron expression like "0 6 * * 1-5" with optional "CRON_TZ=Europe/Berlin " prefix; replaces Interval for the time of the next check
+	HashType            string            `json:"hash_type"`
+	SourceType          string            `json:"source_type" bson:"source_type"`     //selects the registered source that checks the entity; empty is SOURCE_TYPE_HTTP
+	SourceConfig        json.RawMessage   `json:"source_config" bson:"source_config"` //config of non http sources, decoded by the source
+	Watch               HttpRequest       `json:"watch"`                              //watched request of SOURCE_TYPE_HTTP
+	Trigger             HttpRequest       `json:"trigger"`
+	CreatedAt           int64             `json:"created_at"`
+	Paused              bool              `json:"paused" bson:"paused"`
+	TriggerCondition    string            `json:"trigger_condition" bson:"trigger_condition"`         //limits the changes that run the trigger, e.g. "added_only" for the deviceids hash type; empty triggers on every change
+	TriggerInputs       map[string]string `json:"trigger_inputs" bson:"trigger_inputs"`               //maps values known at trigger time (e.g. "added_devices") to maintenance procedure input ids added to the trigger body
+	TriggerResponsePath string            `json:"trigger_response_path" bson:"trigger_response_path"` //json path expression selecting the part of the watched response passed as "response" trigger input; empty passes the whole response
+	HashOptions         `bson:",inline"`
+}
+
+// HashOptions configure the hash types that parse the watched response as json
+type HashOptions struct {
+	HashPaths        []string `json:"hash_paths" bson:"hash_paths"`                 //json path expressions selecting the hashed parts of the response
+	HashSortArrays   bool     `json:"hash_sort_arrays" bson:"hash_sort_arrays"`     //ignore the order of array elements
+	HashIgnoreFields []string `json:"hash_ignore_fields" bson:"hash_ignore_fields"` //field names removed anywhere in the response or json pointers (starting with "/") removed before hashing
+	Predicate        string   `json:"predicate" bson:"predicate"`                   //boolean expression evaluated by the predicate hash type, e.g. "battery < 20"
+	PredicateMode    string   `json:"predicate_mode" bson:"predicate_mode"`         //"edge" (default) triggers when the predicate becomes true (including the first check), "level" on every true evaluation
+	HashStatusCode   bool     `json:"hash_status_code" bson:"hash_status_code"`     //the status code of the response is part of the hash, e.g. to detect a change from 404 to 200 with the same body
 }
 
 type WatchedEntityFetchInfo struct {
-	TimestampOfNextCheck int64  `json:"timestamp_of_next_check" bson:"timestamp_of_next_check"`
-	LastHash             string `json:"last_hash"`
+	TimestampOfNextCheck int64    `json:"timestamp_of_next_check" bson:"timestamp_of_next_check"`
+	LastHash             string   `json:"last_hash"`
+	PendingHash          string   `json:"pending_hash" bson:"pending_hash"`
+	LastDeviceIds        []string `json:"last_device_ids" bson:"last_device_ids"` //device ids found by the last promoted check of the deviceids hash type
+	ETag                 string   `json:"etag" bson:"etag"`                       //ETag header of the response of LastHash, sent as If-None-Match
+	LastModified         string   `json:"last_modified" bson:"last_modified"`     //Last-Modified header of the response of LastHash, sent as If-Modified-Since
+	LeaseOwner           string   `json:"lease_owner" bson:"lease_owner"`
+	LeaseExpiration      int64    `json:"lease_expiration" bson:"lease_expiration"`
+	FailureState         `bson:",inline"`
+}
+
+// FailureState tracks consecutive failed checks; a disabled entity is no longer fetched until it is resumed
+type FailureState struct {
+	ConsecutiveFailures int    `json:"consecutive_failures" bson:"consecutive_failures"`
+	LastError           string `json:"last_error" bson:"last_error"`
+	Disabled            bool   `json:"disabled" bson:"disabled"`
 }
 
 type HttpRequest struct {
@@ -47,4 +85,94 @@ type HttpRequest struct {
 	AddAuthToken bool        `json:"add_auth_token"`
 	Header       http.Header `json:"header"`
 	Isolated     bool        `json:"isolated"`
+
+	Timeout             string `json:"timeout" bson:"timeout"`                             //duration like "30s"; empty uses DefaultRequestTimeout
+	AcceptedStatusCodes []int  `json:"accepted_status_codes" bson:"accepted_status_codes"` //status codes >= 300 that are handled like a successful response, e.g. 404
+}
+
+const DefaultRequestTimeout = 5 * time.Second
+
+// GetTimeout returns the parsed Timeout or DefaultRequestTimeout if none is set
+func (this HttpRequest) GetTimeout() (time.Duration, error) {
+	if this.Timeout == "" {
+		return DefaultRequestTimeout, nil
+	}
+	timeout, err := time.ParseDuration(this.Timeout)
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, errors.New("timeout must be positive")
+	}
+	return timeout, nil
+}
+
+const RedactedValue = "***"
+
+// RedactHeader replaces all header values, because they may contain credentials
+func RedactHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+	result := http.Header{}
+	for k, values := range header {
+		for range values {
+			result[k] = append(result[k], RedactedValue)
+		}
+	}
+	return result
+}
+
+// IsAccepted returns true for status codes < 300 and for status codes listed in AcceptedStatusCodes
+func (this HttpRequest) IsAccepted(statusCode int) bool {
+	return statusCode < 300 || slices.Contains(this.AcceptedStatusCodes, statusCode)
+}
+
+type CheckResult struct {
+	Changed      bool        `json:"changed"`
+	Hash         string      `json:"hash"`
+	StatusCode   int         `json:"status_code"`
+	Payload      []byte      `json:"-"`                     //watched response, used to render trigger inputs
+	ETag         string      `json:"-"`                     //ETag header of the watched response
+	LastModified string      `json:"-"`                     //Last-Modified header of the watched response
+	DeviceIds    []string    `json:"-"`                     //device ids found by the deviceids hash type
+	DeviceDiff   *DeviceDiff `json:"device_diff,omitempty"` //difference to the last device ids, only set by the deviceids hash type
+	Predicate    *bool       `json:"predicate,omitempty"`   //result of the predicate, only set by the predicate hash type
+}
+
+// DeviceDiff lists the device ids added to or removed from the watched response since the last promoted check
+type DeviceDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+type ManualCheckResult struct {
+	CheckResult
+	Triggered bool `json:"triggered"`
+	DryRun    bool `json:"dry_run"`
+}
+
+type HistoryEntry struct {
+	WatcherId  string    `json:"watcher_id" bson:"watcher_id"`
+	UserId     string    `json:"user_id" bson:"user_id"`
+	Timestamp  time.Time `json:"timestamp" bson:"timestamp"`
+	DurationMs int64     `json:"duration_ms" bson:"duration_ms"`
+	StatusCode int       `json:"status_code" bson:"status_code"`
+	OldHash    string    `json:"old_hash" bson:"old_hash"`
+	NewHash    string    `json:"new_hash" bson:"new_hash"`
+	Triggered  bool      `json:"triggered" bson:"triggered"`
+	Error      string    `json:"error" bson:"error"`
+}
+
+// PendingTrigger is a failed trigger that is retried until it succeeds or is dead-lettered after config.TriggerMaxAttempts
+type PendingTrigger struct {
+	Id          string      `json:"id" bson:"id"`
+	WatcherId   string      `json:"watcher_id" bson:"watcher_id"`
+	UserId      string      `json:"user_id" bson:"user_id"`
+	Trigger     HttpRequest `json:"trigger" bson:"trigger"`
+	CreatedAt   time.Time   `json:"created_at" bson:"created_at"`
+	Attempts    int         `json:"attempts" bson:"attempts"`
+	NextAttempt int64       `json:"next_attempt" bson:"next_attempt"`
+	LastError   string      `json:"last_error" bson:"last_error"`
+	DeadLetter  bool        `json:"dead_letter" bson:"dead_letter"`
 }
@@ -0,0 +1,520 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package predicate implements the boolean expressions evaluated against watched json responses,
+// e.g. "battery < 20" or "count($.devices) > 0 && state == 'on'".
+// operands are json paths ($.a.b or the shorthand a.b), numbers, quoted strings, true, false, null
+// and count(path), which returns the length of a single matched array or object, otherwise the number of matches.
+// supported operators are == != < <= > >= ! && || and parentheses.
+// a path matching one value evaluates to this value, a path without match to null and a path with multiple matches to the list of matches.
+package predicate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/jsonpath"
+)
+
+var ErrInvalidExpression = errors.New("invalid predicate expression")
+
+type Expression struct {
+	expression string
+	root       node
+}
+
+type node interface {
+	eval(doc interface{}) interface{}
+}
+
+// Parse parses a complete predicate expression
+func Parse(expression string) (result Expression, err error) {
+	p := &parser{input: expression}
+	result.expression = expression
+	result.root, err = p.parseOr()
+	if err != nil {
+		return result, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return result, p.errorf("unexpected %#v", p.input[p.pos:])
+	}
+	return result, nil
+}
+
+// Evaluate parses the expression and evaluates it against doc
+func Evaluate(expression string, doc interface{}) (bool, error) {
+	e, err := Parse(expression)
+	if err != nil {
+		return false, err
+	}
+	return e.Evaluate(doc), nil
+}
+
+func (this Expression) String() string {
+	return this.expression
+}
+
+// Evaluate returns the truthiness of the expression for doc.
+// doc is expected to be the result of json.Unmarshal into an interface{}.
+func (this Expression) Evaluate(doc interface{}) bool {
+	return truthy(this.root.eval(doc))
+}
+
+// truthy: false, null, 0, "" and empty lists or objects are false, all other values are true
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	default:
+		number, ok := toNumber(v)
+		return !ok || number.Sign() != 0
+	}
+}
+
+// toNumber converts numbers to exact rationals, so that large integers are compared without float rounding
+func toNumber(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case *big.Rat:
+		return v, true
+	case float64:
+		return new(big.Rat).SetFloat64(v), true
+	case json.Number:
+		return parseNumber(v.String())
+	default:
+		return nil, false
+	}
+}
+
+// maxNumberDigits and maxNumberExponent bound the cost of exact comparisons of untrusted numbers:
+// further digits are truncated and the magnitude is clamped, e.g. 1e1000000 is compared as 1e1000
+const maxNumberDigits = 100
+const maxNumberExponent = 1000
+
+// parseNumber converts the text of a json number to a rational without expanding large exponents
+func parseNumber(number string) (*big.Rat, bool) {
+	mantissa, exponentText, hasExponent := strings.Cut(strings.ToLower(number), "e")
+	sign := ""
+	if strings.HasPrefix(mantissa, "-") {
+		sign = "-"
+		mantissa = mantissa[1:]
+	}
+	integer, fraction, _ := strings.Cut(mantissa, ".")
+	digits := integer + fraction
+	if integer == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return nil, false
+	}
+	exponent := -len(fraction)
+	if hasExponent {
+		//ParseInt returns the nearest int32 on range errors, which is clamped below
+		temp, err := strconv.ParseInt(exponentText, 10, 32)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return nil, false
+		}
+		exponent = exponent + int(temp)
+	}
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return new(big.Rat), true
+	}
+	if len(digits) > maxNumberDigits {
+		exponent = exponent + len(digits) - maxNumberDigits
+		digits = digits[:maxNumberDigits]
+	}
+	magnitude := exponent + len(digits) - 1
+	if magnitude > maxNumberExponent {
+		exponent = exponent - (magnitude - maxNumberExponent)
+	}
+	if magnitude < -maxNumberExponent {
+		exponent = exponent + (-maxNumberExponent - magnitude)
+	}
+	return new(big.Rat).SetString(sign + digits + "e" + strconv.Itoa(exponent))
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (this literalNode) eval(interface{}) interface{} {
+	return this.value
+}
+
+type pathNode struct {
+	path jsonpath.Path
+}
+
+func (this pathNode) eval(doc interface{}) interface{} {
+	matches := this.path.Select(doc)
+	switch len(matches) {
+	case 0:
+		return nil
+	case 1:
+		return matches[0]
+	default:
+		return matches
+	}
+}
+
+type countNode struct {
+	path jsonpath.Path
+}
+
+func (this countNode) eval(doc interface{}) interface{} {
+	matches := this.path.Select(doc)
+	if len(matches) == 1 {
+		switch v := matches[0].(type) {
+		case []interface{}:
+			return float64(len(v))
+		case map[string]interface{}:
+			return float64(len(v))
+		}
+	}
+	return float64(len(matches))
+}
+
+type notNode struct {
+	operand node
+}
+
+func (this notNode) eval(doc interface{}) interface{} {
+	return !truthy(this.operand.eval(doc))
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (this andNode) eval(doc interface{}) interface{} {
+	return truthy(this.left.eval(doc)) && truthy(this.right.eval(doc))
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (this orNode) eval(doc interface{}) interface{} {
+	return truthy(this.left.eval(doc)) || truthy(this.right.eval(doc))
+}
+
+type compareNode struct {
+	operator    string
+	left, right node
+}
+
+func (this compareNode) eval(doc interface{}) interface{} {
+	left := this.left.eval(doc)
+	right := this.right.eval(doc)
+	switch this.operator {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	}
+	cmp, ok := compare(left, right)
+	if !ok {
+		return false
+	}
+	switch this.operator {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return false
+	}
+}
+
+// compare orders two numbers or two strings; ok is false for other types
+func compare(a interface{}, b interface{}) (result int, ok bool) {
+	if aNumber, aOk := toNumber(a); aOk {
+		bNumber, bOk := toNumber(b)
+		if !bOk {
+			return 0, false
+		}
+		return aNumber.Cmp(bNumber), true
+	}
+	aString, aOk := a.(string)
+	bString, bOk := b.(string)
+	if !aOk || !bOk {
+		return 0, false
+	}
+	return strings.Compare(aString, bString), true
+}
+
+func equal(a interface{}, b interface{}) bool {
+	if cmp, ok := compare(a, b); ok {
+		return cmp == 0
+	}
+	switch av := a.(type) {
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case nil, bool:
+		return a == b
+	default:
+		return false
+	}
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+var numberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?`)
+
+func (this *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %v at position %v in %#v", ErrInvalidExpression, fmt.Sprintf(format, args...), this.pos, this.input)
+}
+
+func (this *parser) peek() byte {
+	if this.pos < len(this.input) {
+		return this.input[this.pos]
+	}
+	return 0
+}
+
+func (this *parser) skipSpaces() {
+	for this.peek() == ' ' || this.peek() == '\t' || this.peek() == '\n' || this.peek() == '\r' {
+		this.pos++
+	}
+}
+
+// consume skips spaces and the token, if the input continues with it
+func (this *parser) consume(token string) bool {
+	this.skipSpaces()
+	if strings.HasPrefix(this.input[this.pos:], token) {
+		this.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (this *parser) parseOr() (result node, err error) {
+	result, err = this.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for this.consume("||") {
+		right, err := this.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		result = orNode{left: result, right: right}
+	}
+	return result, nil
+}
+
+func (this *parser) parseAnd() (result node, err error) {
+	result, err = this.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for this.consume("&&") {
+		right, err := this.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		result = andNode{left: result, right: right}
+	}
+	return result, nil
+}
+
+func (this *parser) parseNot() (node, error) {
+	this.skipSpaces()
+	if this.peek() == '!' && !strings.HasPrefix(this.input[this.pos:], "!=") {
+		this.pos++
+		operand, err := this.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return this.parseComparison()
+}
+
+func (this *parser) parseComparison() (node, error) {
+	left, err := this.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for _, operator := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if this.consume(operator) {
+			right, err := this.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return compareNode{operator: operator, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (this *parser) parseOperand() (node, error) {
+	this.skipSpaces()
+	c := this.peek()
+	switch {
+	case c == 0:
+		return nil, this.errorf("unexpected end of expression")
+	case c == '(':
+		this.pos++
+		result, err := this.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !this.consume(")") {
+			return nil, this.errorf("expected )")
+		}
+		return result, nil
+	case c == '$':
+		path, err := this.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		return pathNode{path: path}, nil
+	case c == '"' || c == '\'':
+		str, err := this.parseQuoted(c)
+		if err != nil {
+			return nil, err
+		}
+		return literalNode{value: str}, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		number := numberPattern.FindString(this.input[this.pos:])
+		if number == "" {
+			return nil, this.errorf("invalid number")
+		}
+		this.pos += len(number)
+		value, ok := parseNumber(number)
+		if !ok {
+			return nil, this.errorf("invalid number %v", number)
+		}
+		return literalNode{value: value}, nil
+	case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		return this.parseIdentifier()
+	default:
+		return nil, this.errorf("unexpected %#v", string(c))
+	}
+}
+
+// parseIdentifier parses the literals true, false and null, the count function or a path shorthand without leading $.
+func (this *parser) parseIdentifier() (node, error) {
+	start := this.pos
+	for this.pos < len(this.input) && isIdentifierChar(this.input[this.pos]) {
+		this.pos++
+	}
+	switch this.input[start:this.pos] {
+	case "true":
+		return literalNode{value: true}, nil
+	case "false":
+		return literalNode{value: false}, nil
+	case "null":
+		return literalNode{value: nil}, nil
+	case "count":
+		if !this.consume("(") {
+			break
+		}
+		this.skipSpaces()
+		path, err := this.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		if !this.consume(")") {
+			return nil, this.errorf("expected )")
+		}
+		return countNode{path: path}, nil
+	}
+	this.pos = start
+	path, err := this.parsePath()
+	if err != nil {
+		return nil, err
+	}
+	return pathNode{path: path}, nil
+}
+
+// parsePath parses a json path at the current position; paths not starting with $ are relative to the root
+func (this *parser) parsePath() (path jsonpath.Path, err error) {
+	input := this.input[this.pos:]
+	if !strings.HasPrefix(input, "$") {
+		input = "$." + input
+	}
+	path, rest, err := jsonpath.ParsePrefix(input)
+	if err != nil {
+		return path, this.errorf("%v", err)
+	}
+	this.pos += len(this.input[this.pos:]) - len(rest)
+	return path, nil
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (this *parser) parseQuoted(quote byte) (string, error) {
+	this.pos++ // opening quote
+	result := strings.Builder{}
+	for this.pos < len(this.input) {
+		c := this.input[this.pos]
+		switch {
+		case c == quote:
+			this.pos++
+			return result.String(), nil
+		case c == '\\' && this.pos+1 < len(this.input):
+			result.WriteByte(this.input[this.pos+1])
+			this.pos += 2
+		default:
+			result.WriteByte(c)
+			this.pos++
+		}
+	}
+	return "", this.errorf("unterminated string")
+}
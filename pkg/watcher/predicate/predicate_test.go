@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package predicate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+const testDocument = `{
+	"battery": 15,
+	"state": "on",
+	"online": true,
+	"parent": null,
+	"devices": [
+		{"id": "a", "battery": 80},
+		{"id": "b", "battery": 10}
+	],
+	"tags": {},
+	"big": 12345678901234567890,
+	"huge": 1e1000000,
+	"tiny": -1e-1000000,
+	"long": 1.00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001
+}`
+
+func TestEvaluate(t *testing.T) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(testDocument)))
+	decoder.UseNumber()
+	var doc interface{}
+	err := decoder.Decode(&doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]bool{
+		"battery < 20":             true,
+		"battery<20":               true,
+		"$.battery >= 15":          true,
+		"$.battery > 15":           false,
+		"battery == 15.0":          true,
+		"battery != 15":            false,
+		"battery == '15'":          false,
+		"state == 'on'":            true,
+		`state == "off"`:           false,
+		"state < 'z'":              true,
+		"state < 1":                false,
+		"online":                   true,
+		"!online":                  false,
+		"parent == null":           true,
+		"missing == null":          true,
+		"missing":                  false,
+		"!missing":                 true,
+		"missing < 1":              false,
+		"count($.devices) > 0":     true,
+		"count(devices) == 2":      true,
+		"count($.devices[*]) == 2": true,
+		"count(tags) == 0":         true,
+		"count(missing) == 0":      true,
+		"devices[1].battery < 20":  true,
+		"$.devices[0].id == 'a'":   true,
+		"$.devices[*].battery == $.devices[*].battery": true,
+		"battery < 20 && state == 'on'":                true,
+		"battery < 20 && state == 'off'":               false,
+		"battery > 20 || state == 'on'":                true,
+		"!(battery > 20 || state == 'off')":            true,
+		"(battery > 20) == false":                      true,
+		"big > 12345678901234567000":                   true,
+		"tags":                                         false,
+		"devices":                                      true,
+		"-1 < 0":                                       true,
+		"1e2 == 100":                                   true,
+		"huge > 1e999":                                 true,
+		"huge > big":                                   true,
+		"tiny < 0":                                     true,
+		"tiny > -1e-999":                               true,
+		"tiny":                                         true,
+		"long > 1":                                     false,
+		"long == 1":                                    true,
+		"1e1000000 == huge":                            true,
+	}
+	for expression, expected := range cases {
+		t.Run(expression, func(t *testing.T) {
+			result, err := Evaluate(expression, doc)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if result != expected {
+				t.Error(result)
+			}
+		})
+	}
+}
+
+func TestLargeNumbersAreNotExpanded(t *testing.T) {
+	start := time.Now()
+	doc := map[string]interface{}{"a": json.Number("1e999999999"), "b": json.Number("-0.5e-2147483649")}
+	result, err := Evaluate("a > 1e999 && a <= 1e2147483648 && b < 0", doc)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !result {
+		t.Error(result)
+	}
+	if duration := time.Since(start); duration > time.Second {
+		t.Error(duration)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"battery <",
+		"battery < 20 &&",
+		"(battery < 20",
+		"battery < 20)",
+		"state == 'on",
+		"count($.devices",
+		"count($.devices[?]) == 0",
+		"battery = 20",
+		"battery < 20 state",
+		"#",
+	} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := Parse(expression)
+			if !errors.Is(err, ErrInvalidExpression) {
+				t.Error(err)
+			}
+		})
+	}
+}
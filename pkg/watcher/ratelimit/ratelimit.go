@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ratelimit limits the outbound watch and trigger requests per target host with token buckets.
+// throttled requests wait for their token until the deadline of the request context.
+// the request counts and waits of limited hosts are published as expvar "rate_limit";
+// hosts beyond the first MaxMetricsHosts are counted as OtherHosts.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+)
+
+var ErrThrottled = errors.New("rate limit of host exceeded until the request deadline")
+
+const MaxMetricsHosts = 100
+const OtherHosts = "other"
+
+// sweepInterval is the minimal time between removals of refilled buckets
+const sweepInterval = time.Minute
+
+var metrics = expvar.NewMap("rate_limit")
+var metricsMux sync.Mutex
+var metricsHosts = 0
+
+// Metrics returns the published rate limit metrics
+func Metrics() expvar.Var {
+	return metrics
+}
+
+// Limit of one host; a Rate <= 0 does not limit the host
+type Limit struct {
+	Rate  float64 //tokens per second
+	Burst int     //bucket size
+}
+
+type Limiter struct {
+	mux       sync.Mutex
+	limit     Limit
+	overrides map[string]Limit
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+// New creates a limiter from config.RateLimit, config.RateLimitBurst and config.RateLimitOverrides
+func New(config configuration.Config) (*Limiter, error) {
+	burst := max(config.RateLimitBurst, 1)
+	overrides := map[string]Limit{}
+	for host, value := range config.RateLimitOverrides {
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate_limit_overrides value %#v for host %#v: %w", value, host, err)
+		}
+		overrides[strings.ToLower(host)] = Limit{Rate: rate, Burst: burst}
+	}
+	return NewWithClock(Limit{Rate: config.RateLimit, Burst: burst}, overrides, time.Now), nil
+}
+
+// NewWithClock creates a limiter with limit for all hosts without override; now is used as clock
+func NewWithClock(limit Limit, overrides map[string]Limit, now func() time.Time) *Limiter {
+	return &Limiter{
+		limit:     limit,
+		overrides: overrides,
+		buckets:   map[string]*bucket{},
+		now:       now,
+	}
+}
+
+// Wait blocks until the host of endpoint may receive a request or ctx is done.
+// if the token of the host is not available before the deadline of ctx, ErrThrottled is returned without waiting.
+// a nil limiter does not limit any host.
+func (this *Limiter) Wait(ctx context.Context, endpoint string) error {
+	if this == nil {
+		return nil
+	}
+	host := hostOf(endpoint)
+	delay, ok := this.reserve(host)
+	if !ok {
+		return nil
+	}
+	hostMetrics(host).Add("requests", 1)
+	if delay <= 0 {
+		return nil
+	}
+	hostMetrics(host).Add("throttled", 1)
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		this.cancel(host)
+		hostMetrics(host).Add("rejected", 1)
+		return fmt.Errorf("%w: %v needs %v", ErrThrottled, host, delay)
+	}
+	hostMetrics(host).AddFloat("wait_seconds", delay.Seconds())
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		this.cancel(host)
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token of the host and returns the time until the token is available.
+// ok is false if the host is not limited.
+func (this *Limiter) reserve(host string) (delay time.Duration, ok bool) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	now := this.now()
+	if now.Sub(this.lastSweep) >= sweepInterval {
+		this.sweep(now)
+	}
+	b, ok := this.buckets[host]
+	if !ok {
+		limit, override := this.overrides[host]
+		if !override {
+			limit = this.limit
+		}
+		if limit.Rate <= 0 {
+			return 0, false
+		}
+		b = &bucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		this.buckets[host] = b
+	}
+	b.tokens = b.available(now)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0, true
+	}
+	return time.Duration(-b.tokens / b.limit.Rate * float64(time.Second)), true
+}
+
+// sweep removes the refilled buckets, which are equal to the new bucket of their next request
+func (this *Limiter) sweep(now time.Time) {
+	for host, b := range this.buckets {
+		if b.available(now) >= float64(b.limit.Burst) {
+			delete(this.buckets, host)
+		}
+	}
+	this.lastSweep = now
+}
+
+// cancel returns the token of a reservation that was not used
+func (this *Limiter) cancel(host string) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if b, ok := this.buckets[host]; ok {
+		b.tokens = min(float64(b.limit.Burst), b.tokens+1)
+	}
+}
+
+// Buckets returns the number of hosts with tokens in use
+func (this *Limiter) Buckets() int {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return len(this.buckets)
+}
+
+func (this *bucket) available(now time.Time) float64 {
+	return min(float64(this.limit.Burst), this.tokens+now.Sub(this.last).Seconds()*this.limit.Rate)
+}
+
+func hostOf(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+func hostMetrics(host string) *expvar.Map {
+	metricsMux.Lock()
+	defer metricsMux.Unlock()
+	if m, ok := metrics.Get(host).(*expvar.Map); ok {
+		return m
+	}
+	if metricsHosts >= MaxMetricsHosts {
+		host = OtherHosts
+		if m, ok := metrics.Get(host).(*expvar.Map); ok {
+			return m
+		}
+	}
+	m := new(expvar.Map)
+	metrics.Set(host, m)
+	metricsHosts++
+	return m
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package schedule implements the cron expressions of watcher schedules,
+// e.g. "0 6 * * 1-5" for every weekday at 06:00 or "*/15 8-17 * * MON-FRI" for every 15 minutes during business hours.
+// the five fields are minute, hour, day of month, month and day of week. every field accepts *, numbers, ranges (a-b),
+// lists (a,b) and steps (*/n, a-b/n, a/n); months and days of week may be given by name (JAN, MON) and 7 is sunday.
+// if day of month and day of week are both restricted, days matching either field are scheduled.
+// an optional "CRON_TZ=<zone> " or "TZ=<zone> " prefix selects the time zone, otherwise UTC is used.
+// the descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly replace the five fields.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// searchLimit bounds the search of Next for schedules without reachable date, e.g. "0 0 30 2 *"
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+type Schedule struct {
+	expression    string
+	location      *time.Location
+	minute        uint64
+	hour          uint64
+	dayOfMonth    uint64
+	month         uint64
+	dayOfWeek     uint64
+	domRestricted bool
+	dowRestricted bool
+}
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField     = field{name: "minute", min: 0, max: 59}
+	hourField       = field{name: "hour", min: 0, max: 23}
+	dayOfMonthField = field{name: "day of month", min: 1, max: 31}
+	monthField      = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6, "JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	dayOfWeekField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a complete schedule expression
+func Parse(expression string) (result Schedule, err error) {
+	result.expression = expression
+	result.location = time.UTC
+	rest := strings.TrimSpace(expression)
+	if strings.HasPrefix(rest, "CRON_TZ=") || strings.HasPrefix(rest, "TZ=") {
+		zone, fields, _ := strings.Cut(rest, " ")
+		_, zone, _ = strings.Cut(zone, "=")
+		result.location, err = time.LoadLocation(zone)
+		if err != nil {
+			return result, fmt.Errorf("%w: unknown time zone %#v in %#v", ErrInvalidSchedule, zone, expression)
+		}
+		rest = strings.TrimSpace(fields)
+	}
+	if strings.HasPrefix(rest, "@") {
+		replacement, ok := descriptors[rest]
+		if !ok {
+			return result, fmt.Errorf("%w: unknown descriptor %#v in %#v", ErrInvalidSchedule, rest, expression)
+		}
+		rest = replacement
+	}
+	fields := strings.Fields(rest)
+	if len(fields) != 5 {
+		return result, fmt.Errorf("%w: expected 5 fields, got %v in %#v", ErrInvalidSchedule, len(fields), expression)
+	}
+	targets := []*uint64{&result.minute, &result.hour, &result.dayOfMonth, &result.month, &result.dayOfWeek}
+	for i, f := range []field{minuteField, hourField, dayOfMonthField, monthField, dayOfWeekField} {
+		*targets[i], err = f.parse(fields[i])
+		if err != nil {
+			return result, fmt.Errorf("%w: %v in %#v", ErrInvalidSchedule, err, expression)
+		}
+	}
+	if result.dayOfWeek&(1<<7) != 0 {
+		result.dayOfWeek |= 1 << 0
+	}
+	result.domRestricted = !strings.HasPrefix(fields[2], "*")
+	result.dowRestricted = !strings.HasPrefix(fields[4], "*")
+	return result, nil
+}
+
+func (this Schedule) String() string {
+	return this.expression
+}
+
+// Next returns the first scheduled minute after t, in the time zone of t.
+// the zero time is returned if no scheduled minute is found within 5 years.
+func (this Schedule) Next(t time.Time) time.Time {
+	local := t.In(this.location)
+	limit := local.Add(searchLimit)
+	current := local.Truncate(time.Minute).Add(time.Minute)
+	for current.Before(limit) {
+		year, month, day := current.Date()
+		hour, minute := current.Hour(), current.Minute()
+		switch {
+		case !has(this.month, int(month)):
+			current = time.Date(year, month+1, 1, 0, 0, 0, 0, this.location)
+		case !this.matchesDay(current):
+			current = time.Date(year, month, day+1, 0, 0, 0, 0, this.location)
+		case !has(this.hour, hour):
+			current = time.Date(year, month, day, hour+1, 0, 0, 0, this.location)
+		case !has(this.minute, minute):
+			current = current.Add(time.Minute)
+		default:
+			return current.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
+
+func (this Schedule) matchesDay(t time.Time) bool {
+	domMatch := has(this.dayOfMonth, t.Day())
+	dowMatch := has(this.dayOfWeek, int(t.Weekday()))
+	if this.domRestricted && this.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+// parse returns the values of a comma separated list of elements as bit set
+func (this field) parse(expression string) (result uint64, err error) {
+	for _, element := range strings.Split(expression, ",") {
+		values, err := this.parseElement(element)
+		if err != nil {
+			return 0, err
+		}
+		result |= values
+	}
+	return result, nil
+}
+
+// parseElement handles *, a, a-b with an optional /step
+func (this field) parseElement(element string) (result uint64, err error) {
+	rangeExpression, stepExpression, hasStep := strings.Cut(element, "/")
+	step := 1
+	if hasStep {
+		step, err = strconv.Atoi(stepExpression)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step %#v in %v field", stepExpression, this.name)
+		}
+	}
+	var from, to int
+	switch {
+	case rangeExpression == "*":
+		from, to = this.min, this.max
+	case strings.Contains(rangeExpression, "-"):
+		fromExpression, toExpression, _ := strings.Cut(rangeExpression, "-")
+		from, err = this.parseValue(fromExpression)
+		if err != nil {
+			return 0, err
+		}
+		to, err = this.parseValue(toExpression)
+		if err != nil {
+			return 0, err
+		}
+		if to < from {
+			return 0, fmt.Errorf("invalid range %#v in %v field", rangeExpression, this.name)
+		}
+	default:
+		from, err = this.parseValue(rangeExpression)
+		if err != nil {
+			return 0, err
+		}
+		to = from
+		if hasStep {
+			to = this.max
+		}
+	}
+	for value := from; value <= to; value += step {
+		result |= 1 << uint(value)
+	}
+	return result, nil
+}
+
+func (this field) parseValue(expression string) (int, error) {
+	if value, ok := this.names[strings.ToUpper(expression)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(expression)
+	if err != nil || value < this.min || value > this.max {
+		return 0, fmt.Errorf("invalid value %#v in %v field, expected %v-%v", expression, this.name, this.min, this.max)
+	}
+	return value, nil
+}
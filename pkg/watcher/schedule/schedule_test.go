@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	//2026-10-16 is a friday
+	start := time.Date(2026, 10, 16, 10, 7, 30, 0, time.UTC)
+	cases := []struct {
+		expression string
+		expected   []string
+	}{
+		{"*/15 * * * *", []string{"2026-10-16T10:15:00Z", "2026-10-16T10:30:00Z", "2026-10-16T10:45:00Z"}},
+		{"0 6 * * 1-5", []string{"2026-10-19T06:00:00Z", "2026-10-20T06:00:00Z"}},
+		{"*/30 16-17 * * MON-FRI", []string{"2026-10-16T16:00:00Z", "2026-10-16T16:30:00Z", "2026-10-16T17:00:00Z", "2026-10-16T17:30:00Z", "2026-10-19T16:00:00Z"}},
+		{"0 0 1 * *", []string{"2026-11-01T00:00:00Z", "2026-12-01T00:00:00Z"}},
+		{"@hourly", []string{"2026-10-16T11:00:00Z", "2026-10-16T12:00:00Z"}},
+		{"0 12 * * 7", []string{"2026-10-18T12:00:00Z", "2026-10-25T12:00:00Z"}},
+		{"0 12 13 * 5", []string{"2026-10-16T12:00:00Z", "2026-10-23T12:00:00Z", "2026-10-30T12:00:00Z", "2026-11-06T12:00:00Z", "2026-11-13T12:00:00Z", "2026-11-20T12:00:00Z"}},
+		{"0 0 29 2 *", []string{"2028-02-29T00:00:00Z"}},
+		{"5/20 10 * * *", []string{"2026-10-16T10:25:00Z", "2026-10-16T10:45:00Z", "2026-10-17T10:05:00Z"}},
+		{"0 9 * jan,OCT *", []string{"2026-10-17T09:00:00Z"}},
+		{"CRON_TZ=Europe/Berlin 0 6 * * *", []string{"2026-10-17T04:00:00Z", "2026-10-18T04:00:00Z"}},
+		{"CRON_TZ=Europe/Berlin 0 6 24-31 10 *", []string{"2026-10-24T04:00:00Z", "2026-10-25T05:00:00Z"}}, //end of daylight saving time
+		{"TZ=America/New_York 30 12 * * *", []string{"2026-10-16T16:30:00Z"}},
+	}
+	for _, c := range cases {
+		t.Run(c.expression, func(t *testing.T) {
+			s, err := Parse(c.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			current := start
+			found := 0
+			for found < len(c.expected) {
+				current = s.Next(current)
+				if current.IsZero() {
+					t.Fatal("unexpected zero time")
+				}
+				if current.Location() != time.UTC {
+					t.Fatal("expected location of the input", current.Location())
+				}
+				formatted := current.Format(time.RFC3339)
+				if formatted != c.expected[found] {
+					t.Fatalf("%v: %v != %v", found, formatted, c.expected[found])
+				}
+				found++
+			}
+		})
+	}
+}
+
+func TestNextUnreachable(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := s.Next(time.Now()); !next.IsZero() {
+		t.Error(next)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"* * * FOO *",
+		"@often",
+		"CRON_TZ=Nowhere/Unknown * * * * *",
+	} {
+		_, err := Parse(expression)
+		if !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("%#v: %v", expression, err)
+		}
+	}
+}
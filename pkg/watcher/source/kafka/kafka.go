@@ -0,0 +1,405 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/predicate"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source"
+)
+
+const SOURCE_TYPE = "kafka"
+
+// HASH_NO_MESSAGE is the hash of a new entity until the first matching message is consumed
+const HASH_NO_MESSAGE = "no-message"
+
+const defaultDebounce = time.Second
+const defaultResyncInterval = time.Minute
+const readRetryWait = 5 * time.Second
+const leaseRetryWait = time.Second
+
+// Config is the model.WatchedEntityInit.SourceConfig of SOURCE_TYPE
+type Config struct {
+	Topic    string `json:"topic"`
+	Key      string `json:"key"`      //only messages with this key match; empty matches every key
+	Filter   string `json:"filter"`   //predicate expression evaluated on the json message value, e.g. "device_id == \"foo\""; empty matches every message
+	Debounce string `json:"debounce"` //duration to wait for further matching messages before the check is requested; default 1s
+}
+
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+}
+
+// Reader consumes new messages of one topic
+type Reader interface {
+	ReadMessage(ctx context.Context) (Message, error)
+	Close() error
+}
+
+type ReaderFactory func(topic string) (Reader, error)
+
+// Source consumes the topics of all entities of SOURCE_TYPE. the hash of an entity identifies the last matching message (<partition>:<offset>);
+// after a matching message and the debounce duration, the check of the entity is requested from the Notifier.
+// all instances share one consumer group, so every message is handled by the single instance that owns its partition.
+// therefore every instance subscribes all entities on start and resyncs them with config.KafkaResyncInterval.
+type Source struct {
+	config         configuration.Config
+	newReader      ReaderFactory
+	resyncInterval time.Duration
+	mux            sync.Mutex
+	ctx            context.Context
+	wg             *sync.WaitGroup
+	notifier       source.Notifier
+	topics         map[string]bool
+	subscriptions  map[string]*subscription
+}
+
+type subscription struct {
+	userId   string
+	id       string
+	topic    string
+	key      string
+	filter   *predicate.Expression
+	debounce time.Duration
+	hash     string
+	payload  []byte
+	pending  bool //a message was consumed by this instance, that is not yet checked
+	timer    *time.Timer
+}
+
+func New(config configuration.Config, newReader ReaderFactory) *Source {
+	return &Source{
+		config:         config,
+		newReader:      newReader,
+		resyncInterval: config.ParseDuration("kafka_resync_interval", config.KafkaResyncInterval, defaultResyncInterval),
+		topics:         map[string]bool{},
+		subscriptions:  map[string]*subscription{},
+	}
+}
+
+// Start subscribes all entities of SOURCE_TYPE and consumes their topics until ctx is done
+func (this *Source) Start(ctx context.Context, wg *sync.WaitGroup, notifier source.Notifier) error {
+	this.mux.Lock()
+	this.ctx = ctx
+	this.wg = wg
+	this.notifier = notifier
+	this.mux.Unlock()
+	err := this.resync()
+	if err != nil {
+		return err
+	}
+	if wg != nil {
+		wg.Add(1)
+	}
+	go func() {
+		defer func() {
+			if wg != nil {
+				wg.Done()
+			}
+		}()
+		ticker := time.NewTicker(this.resyncInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := this.resync()
+				if err != nil {
+					this.config.GetLogger().Error("ERROR: unable to resync kafka subscriptions", "error", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// resync subscribes new entities, e.g. created by other instances, and removes subscriptions of deleted entities
+func (this *Source) resync() error {
+	entities, err := this.notifier.ListBySourceType(SOURCE_TYPE)
+	if err != nil {
+		return err
+	}
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	keys := map[string]bool{}
+	for _, entity := range entities {
+		sub, err := parseConfig(entity.SourceConfig)
+		if err != nil {
+			this.config.GetLogger().Warn("WARNING: ignore kafka watcher with invalid config", "watcher", entity.Id, "error", err)
+			continue
+		}
+		sub.userId = entity.UserId
+		sub.id = entity.Id
+		sub = this.subscribe(sub, entity.LastHash)
+		keys[sub.userId+"/"+sub.id] = true
+		err = this.consumeTopic(sub.topic)
+		if err != nil {
+			return err
+		}
+	}
+	for key, sub := range this.subscriptions {
+		if !keys[key] {
+			if sub.timer != nil {
+				sub.timer.Stop()
+			}
+			delete(this.subscriptions, key)
+		}
+	}
+	return nil
+}
+
+// Validate rejects hash types, hash options and trigger conditions, because the hash of a kafka entity identifies the last matching message
+// and is not computed from its payload; messages are selected with Config.Key and Config.Filter instead
+func (this *Source) Validate(entity model.WatchedEntityInit) error {
+	if entity.HashType != "" && entity.HashType != checker.HASH_TYPE_MD5 {
+		return fmt.Errorf("hash_type %#v is not supported by kafka watchers, use the kafka filter to select messages", entity.HashType)
+	}
+	if len(entity.HashPaths) > 0 || entity.HashSortArrays || len(entity.HashIgnoreFields) > 0 || entity.HashStatusCode {
+		return errors.New("hash options are not supported by kafka watchers, every matching message is a change")
+	}
+	if entity.Predicate != "" || entity.PredicateMode != "" {
+		return errors.New("predicate is not supported by kafka watchers, use the kafka filter to select messages")
+	}
+	if entity.TriggerCondition != "" {
+		return fmt.Errorf("trigger_condition %#v is not supported by kafka watchers", entity.TriggerCondition)
+	}
+	_, err := parseConfig(entity.SourceConfig)
+	return err
+}
+
+// CheckEntity returns the hash of the last message consumed by this instance, if it is not yet checked.
+// otherwise the last hash of the entity is returned unchanged, because messages of other partitions are handled by other instances
+func (this *Source) CheckEntity(entity model.WatchedEntity) (result model.CheckResult, err error) {
+	sub, err := parseConfig(entity.SourceConfig)
+	if err != nil {
+		return result, err
+	}
+	sub.userId = entity.UserId
+	sub.id = entity.Id
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	sub = this.subscribe(sub, entity.LastHash)
+	if sub.hash == entity.LastHash {
+		sub.pending = false
+	}
+	if sub.pending {
+		result.Hash = sub.hash
+		result.Payload = sub.payload
+	} else {
+		result.Hash = entity.LastHash
+	}
+	if result.Hash == "" {
+		result.Hash = HASH_NO_MESSAGE
+	}
+	result.Changed = entity.LastHash != result.Hash
+	return result, this.consumeTopic(sub.topic)
+}
+
+func parseConfig(raw json.RawMessage) (sub *subscription, err error) {
+	config, err := source.DecodeConfig[Config](raw)
+	if err != nil {
+		return nil, err
+	}
+	if config.Topic == "" {
+		return nil, errors.New("missing kafka topic")
+	}
+	sub = &subscription{topic: config.Topic, key: config.Key, debounce: defaultDebounce}
+	if config.Filter != "" {
+		filter, err := predicate.Parse(config.Filter)
+		if err != nil {
+			return nil, err
+		}
+		sub.filter = &filter
+	}
+	if config.Debounce != "" {
+		sub.debounce, err = time.ParseDuration(config.Debounce)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kafka debounce: %w", err)
+		}
+		if sub.debounce < 0 {
+			return nil, errors.New("invalid kafka debounce: negative duration")
+		}
+	}
+	return sub, nil
+}
+
+// subscribe stores the config of the entity and returns the current subscription;
+// a new subscription or a changed topic starts with the last hash of the entity
+func (this *Source) subscribe(sub *subscription, lastHash string) *subscription {
+	key := sub.userId + "/" + sub.id
+	existing, ok := this.subscriptions[key]
+	if ok && existing.topic == sub.topic {
+		existing.key = sub.key
+		existing.filter = sub.filter
+		existing.debounce = sub.debounce
+		return existing
+	}
+	if ok && existing.timer != nil {
+		existing.timer.Stop()
+	}
+	sub.hash = lastHash
+	this.subscriptions[key] = sub
+	return sub
+}
+
+func (this *Source) unsubscribe(sub *subscription) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	key := sub.userId + "/" + sub.id
+	if this.subscriptions[key] == sub {
+		delete(this.subscriptions, key)
+	}
+}
+
+// Release implements source.ReleasingSource
+func (this *Source) Release(userId string, id string) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	key := userId + "/" + id
+	if sub, ok := this.subscriptions[key]; ok {
+		if sub.timer != nil {
+			sub.timer.Stop()
+		}
+		delete(this.subscriptions, key)
+	}
+}
+
+// consumeTopic starts the reader of the topic, if Start was called and no reader is running
+func (this *Source) consumeTopic(topic string) error {
+	if this.ctx == nil || this.topics[topic] {
+		return nil
+	}
+	reader, err := this.newReader(topic)
+	if err != nil {
+		return err
+	}
+	this.topics[topic] = true
+	if this.wg != nil {
+		this.wg.Add(1)
+	}
+	go func() {
+		defer func() {
+			reader.Close()
+			if this.wg != nil {
+				this.wg.Done()
+			}
+		}()
+		for {
+			msg, err := reader.ReadMessage(this.ctx)
+			if this.ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				this.config.GetLogger().Error("ERROR: unable to read kafka message", "topic", topic, "error", err)
+				select {
+				case <-this.ctx.Done():
+					return
+				case <-time.After(readRetryWait):
+				}
+				continue
+			}
+			this.handle(msg)
+		}
+	}()
+	return nil
+}
+
+func (this *Source) handle(msg Message) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	var value interface{}
+	valueParsed := false
+	for _, sub := range this.subscriptions {
+		if sub.topic != msg.Topic {
+			continue
+		}
+		if sub.key != "" && sub.key != string(msg.Key) {
+			continue
+		}
+		if sub.filter != nil {
+			if !valueParsed {
+				valueParsed = true
+				if json.Unmarshal(msg.Value, &value) != nil {
+					value = nil
+				}
+			}
+			if value == nil || !sub.filter.Evaluate(value) {
+				continue
+			}
+		}
+		sub.hash = strconv.Itoa(msg.Partition) + ":" + strconv.FormatInt(msg.Offset, 10)
+		sub.payload = msg.Value
+		sub.pending = true
+		this.schedule(sub, sub.debounce)
+	}
+}
+
+// schedule requests the check of the subscription after wait; a scheduled check is replaced
+func (this *Source) schedule(sub *subscription, wait time.Duration) {
+	if sub.timer != nil {
+		sub.timer.Stop()
+	}
+	sub.timer = time.AfterFunc(wait, func() {
+		this.notify(sub)
+	})
+}
+
+// notify requests the check of the subscription; a check of a leased entity is retried after leaseRetryWait.
+// the message stays pending if the entity is paused, so that it is checked after the entity is resumed
+func (this *Source) notify(sub *subscription) {
+	if this.ctx.Err() != nil {
+		return
+	}
+	this.mux.Lock()
+	hash := sub.hash
+	this.mux.Unlock()
+	checked, err := this.notifier.Notify(sub.userId, sub.id)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		this.unsubscribe(sub)
+	case errors.Is(err, db.ErrLeased):
+		this.mux.Lock()
+		if this.subscriptions[sub.userId+"/"+sub.id] == sub && sub.hash == hash {
+			this.schedule(sub, leaseRetryWait)
+		}
+		this.mux.Unlock()
+	case err != nil:
+		this.config.GetLogger().Error("ERROR: unable to check watcher after kafka message", "watcher", sub.id, "error", err)
+	case checked:
+		this.mux.Lock()
+		if sub.hash == hash {
+			sub.pending = false
+		}
+		this.mux.Unlock()
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/segmentio/kafka-go"
+)
+
+const DefaultConsumerGroup = "smart-service-module-worker-watcher"
+
+// NewReaderFactory returns readers of config.KafkaUrl. all instances share config.KafkaConsumerGroup (default DefaultConsumerGroup),
+// so that every message is handled by one instance and consumption continues at the committed offset after a restart.
+func NewReaderFactory(config configuration.Config) ReaderFactory {
+	groupId := config.KafkaConsumerGroup
+	if groupId == "" {
+		groupId = DefaultConsumerGroup
+	}
+	return func(topic string) (Reader, error) {
+		return &kafkaReader{reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     []string{config.KafkaUrl},
+			GroupID:     groupId,
+			Topic:       topic,
+			StartOffset: kafka.LastOffset,
+			MaxWait:     time.Second,
+		})}, nil
+	}
+}
+
+type kafkaReader struct {
+	reader *kafka.Reader
+}
+
+func (this *kafkaReader) ReadMessage(ctx context.Context) (Message, error) {
+	msg, err := this.reader.ReadMessage(ctx)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Value:     msg.Value,
+	}, nil
+}
+
+func (this *kafkaReader) Close() error {
+	return this.reader.Close()
+}
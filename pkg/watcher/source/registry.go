@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+)
+
+var ErrUnknownSourceType = errors.New("unknown source type")
+var ErrAlreadyRegistered = errors.New("source type already registered")
+
+// Source checks watched entities of one model.WatchedEntityInit.SourceType
+type Source interface {
+	CheckEntity(entity model.WatchedEntity) (result model.CheckResult, err error)
+	// Validate rejects entities the source is unable to check, e.g. because their SourceConfig is invalid
+	Validate(entity model.WatchedEntityInit) error
+}
+
+// PushSource is a Source that learns about changes without polling and requests checks from the Notifier
+type PushSource interface {
+	Source
+	Start(ctx context.Context, wg *sync.WaitGroup, notifier Notifier) error
+}
+
+// Notifier is implemented by watcher.Watcher
+type Notifier interface {
+	// Notify checks the entity after the source received a possible change; paused and disabled entities are skipped (checked is false).
+	// db.ErrLeased is returned while another check of the entity is running
+	Notify(userId string, watcherId string) (checked bool, err error)
+	// ListBySourceType returns the entities of all users with the source type
+	ListBySourceType(sourceType string) ([]model.WatchedEntity, error)
+}
+
+// ReleasingSource is a Source that holds resources per entity, which are released after the entity is deleted
+type ReleasingSource interface {
+	Source
+	Release(userId string, id string)
+}
+
+// ConfigRedactor is a Source with credentials in its SourceConfig, which are redacted before entities are returned by the api
+type ConfigRedactor interface {
+	Source
+	RedactConfig(config json.RawMessage) json.RawMessage
+}
+
+// Registry implements watcher.Checker by delegating to the Source registered for the source type of the entity.
+// entities without source type are handled by model.SOURCE_TYPE_HTTP.
+type Registry struct {
+	mux     sync.RWMutex
+	sources map[string]Source
+}
+
+func NewRegistry() *Registry {
+	return &Registry{sources: map[string]Source{}}
+}
+
+func (this *Registry) Register(sourceType string, source Source) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if sourceType == "" {
+		return errors.New("missing source type")
+	}
+	if _, ok := this.sources[sourceType]; ok {
+		return fmt.Errorf("%w: %v", ErrAlreadyRegistered, sourceType)
+	}
+	this.sources[sourceType] = source
+	return nil
+}
+
+// SourceTypes returns the sorted list of registered source types
+func (this *Registry) SourceTypes() (result []string) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	for sourceType := range this.sources {
+		result = append(result, sourceType)
+	}
+	slices.Sort(result)
+	return result
+}
+
+func (this *Registry) Get(sourceType string) (Source, error) {
+	if sourceType == "" {
+		sourceType = model.SOURCE_TYPE_HTTP
+	}
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	source, ok := this.sources[sourceType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownSourceType, sourceType)
+	}
+	return source, nil
+}
+
+// Start starts all registered push sources, ordered by source type
+func (this *Registry) Start(ctx context.Context, wg *sync.WaitGroup, notifier Notifier) error {
+	sourceTypes := this.SourceTypes()
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	for _, sourceType := range sourceTypes {
+		if push, ok := this.sources[sourceType].(PushSource); ok {
+			err := push.Start(ctx, wg, notifier)
+			if err != nil {
+				return fmt.Errorf("unable to start %v source: %w", sourceType, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Release informs all releasing sources about the deleted entity
+func (this *Registry) Release(userId string, id string) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	for _, source := range this.sources {
+		if releasing, ok := source.(ReleasingSource); ok {
+			releasing.Release(userId, id)
+		}
+	}
+}
+
+// RedactSourceConfig returns the SourceConfig of the entity redacted by its source; unknown source types return an empty config
+func (this *Registry) RedactSourceConfig(entity model.WatchedEntityInit) json.RawMessage {
+	source, err := this.Get(entity.SourceType)
+	if err != nil {
+		return nil
+	}
+	if redactor, ok := source.(ConfigRedactor); ok {
+		return redactor.RedactConfig(entity.SourceConfig)
+	}
+	return entity.SourceConfig
+}
+
+func (this *Registry) CheckEntity(entity model.WatchedEntity) (result model.CheckResult, err error) {
+	source, err := this.Get(entity.SourceType)
+	if err != nil {
+		return result, err
+	}
+	return source.CheckEntity(entity)
+}
+
+func (this *Registry) Validate(entity model.WatchedEntityInit) error {
+	source, err := this.Get(entity.SourceType)
+	if err != nil {
+		return err
+	}
+	return source.Validate(entity)
+}
+
+// DecodeConfig decodes the SourceConfig of an entity into the config struct of a source; unknown fields are rejected
+func DecodeConfig[T any](raw json.RawMessage) (result T, err error) {
+	if len(raw) == 0 {
+		return result, errors.New("missing source config")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&result)
+	if err != nil {
+		return result, fmt.Errorf("invalid source config: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,442 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source"
+)
+
+const SOURCE_TYPE = "sse"
+
+const FORMAT_SSE = "sse"
+const FORMAT_LINES = "lines"
+
+// HASH_NO_EVENT is the hash of a new entity until the first event is received
+const HASH_NO_EVENT = "no-event"
+
+const defaultReconnectBackoff = time.Second
+const defaultMaxReconnectBackoff = 5 * time.Minute
+const defaultMaxEventSize = 10 << 20
+const leaseRetryWait = time.Second
+const defaultResyncInterval = time.Minute
+
+// Config is the model.WatchedEntityInit.SourceConfig of SOURCE_TYPE
+type Config struct {
+	Endpoint     string      `json:"endpoint"`
+	Header       http.Header `json:"header"`
+	AddAuthToken bool        `json:"add_auth_token"`
+	Format       string      `json:"format"` //"sse" (default) for text/event-stream, "lines" for chunked streams with one event per line
+	Event        string      `json:"event"`  //only sse events of this type are handled; empty handles every event
+}
+
+// Source holds one streaming connection per entity. every event is hashed like a watched http response
+// with the hash type of the entity and the check of the entity is requested from the Notifier.
+// entities connect on Start, with the periodic resync or with their first check; a closed or failed connection is reopened with exponential backoff.
+//
+// every replica of the worker opens its own connection per entity, so the endpoint receives one stream per replica.
+// the check of an event is deduplicated by the lease of the entity: the replica claiming the lease stores the hash of the event,
+// the other replicas check the same event afterwards and find the hash unchanged.
+// events arriving at the replicas in a different order, e.g. after a reconnect, may trigger an entity more than once;
+// endpoints with costly streams or triggers that are not idempotent should be watched by a single replica.
+type Source struct {
+	config              configuration.Config
+	auth                checker.Auth
+	client              *http.Client
+	reconnectBackoff    time.Duration
+	maxReconnectBackoff time.Duration
+	maxEventSize        int
+	resyncInterval      time.Duration
+	mux                 sync.Mutex
+	ctx                 context.Context
+	wg                  *sync.WaitGroup
+	notifier            source.Notifier
+	connections         map[string]*connection
+}
+
+type connection struct {
+	userId      string
+	id          string
+	rawConfig   string
+	config      Config
+	cancel      context.CancelFunc
+	received    bool
+	payload     []byte
+	lastEventId string
+}
+
+// New uses the isolated client of configuration.Config.GetSaveHttpClient for all connections
+func New(config configuration.Config, auth checker.Auth) *Source {
+	client := config.GetSaveHttpClient()
+	client.Timeout = 0 //streams are held open until the entity is released
+	return NewWithClient(config, auth, client)
+}
+
+// NewWithClient replaces the isolated client, e.g. for tests against local servers
+func NewWithClient(config configuration.Config, auth checker.Auth, client *http.Client) *Source {
+	maxEventSize := int(config.MaxResponseSize)
+	if maxEventSize <= 0 {
+		maxEventSize = defaultMaxEventSize
+	}
+	return &Source{
+		config:              config,
+		auth:                auth,
+		client:              client,
+		reconnectBackoff:    config.ParseDuration("sse_reconnect_backoff", config.SseReconnectBackoff, defaultReconnectBackoff),
+		maxReconnectBackoff: config.ParseDuration("sse_max_reconnect_backoff", config.SseMaxReconnectBackoff, defaultMaxReconnectBackoff),
+		maxEventSize:        maxEventSize,
+		resyncInterval:      config.ParseDuration("sse_resync_interval", config.SseResyncInterval, defaultResyncInterval),
+		connections:         map[string]*connection{},
+	}
+}
+
+// Start enables connections; entities checked before and all stored entities of SOURCE_TYPE are connected now
+func (this *Source) Start(ctx context.Context, wg *sync.WaitGroup, notifier source.Notifier) error {
+	this.mux.Lock()
+	this.ctx = ctx
+	this.wg = wg
+	this.notifier = notifier
+	for _, conn := range this.connections {
+		this.run(conn)
+	}
+	this.mux.Unlock()
+	err := this.resync()
+	if err != nil {
+		return err
+	}
+	if wg != nil {
+		wg.Add(1)
+	}
+	go func() {
+		defer func() {
+			if wg != nil {
+				wg.Done()
+			}
+		}()
+		ticker := time.NewTicker(this.resyncInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := this.resync()
+				if err != nil {
+					this.config.GetLogger().Error("ERROR: unable to resync sse connections", "error", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// resync connects new entities, e.g. created by other instances, and closes the connections of deleted entities
+func (this *Source) resync() error {
+	entities, err := this.notifier.ListBySourceType(SOURCE_TYPE)
+	if err != nil {
+		return err
+	}
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	keys := map[string]bool{}
+	for _, entity := range entities {
+		config, err := parseConfig(entity.SourceConfig)
+		if err != nil {
+			this.config.GetLogger().Warn("WARNING: ignore sse watcher with invalid config", "watcher", entity.Id, "error", err)
+			continue
+		}
+		this.connect(entity, config)
+		keys[entity.UserId+"/"+entity.Id] = true
+	}
+	for key, conn := range this.connections {
+		if !keys[key] {
+			if conn.cancel != nil {
+				conn.cancel()
+			}
+			delete(this.connections, key)
+		}
+	}
+	return nil
+}
+
+func (this *Source) Validate(entity model.WatchedEntityInit) error {
+	_, err := parseConfig(entity.SourceConfig)
+	return err
+}
+
+func parseConfig(raw json.RawMessage) (config Config, err error) {
+	config, err = source.DecodeConfig[Config](raw)
+	if err != nil {
+		return config, err
+	}
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return config, fmt.Errorf("invalid sse endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return config, fmt.Errorf("invalid sse endpoint %#v: expect http or https url", config.Endpoint)
+	}
+	switch config.Format {
+	case "", FORMAT_SSE, FORMAT_LINES:
+	default:
+		return config, fmt.Errorf("unknown sse format %#v", config.Format)
+	}
+	return config, nil
+}
+
+// RedactConfig implements source.ConfigRedactor; header values may contain credentials
+func (this *Source) RedactConfig(raw json.RawMessage) json.RawMessage {
+	config := Config{}
+	err := json.Unmarshal(raw, &config)
+	if err != nil {
+		return nil
+	}
+	config.Header = model.RedactHeader(config.Header)
+	result, err := json.Marshal(config)
+	if err != nil {
+		return nil
+	}
+	return result
+}
+
+func (this *Source) CheckEntity(entity model.WatchedEntity) (result model.CheckResult, err error) {
+	config, err := parseConfig(entity.SourceConfig)
+	if err != nil {
+		return result, err
+	}
+	this.mux.Lock()
+	conn := this.connect(entity, config)
+	received, payload := conn.received, conn.payload
+	this.mux.Unlock()
+	if received {
+		return checker.CheckPayload(entity, payload)
+	}
+	result.Hash = entity.LastHash
+	if result.Hash == "" {
+		result.Hash = HASH_NO_EVENT
+	}
+	result.Changed = entity.LastHash != result.Hash
+	return result, nil
+}
+
+// connect returns the connection of the entity; a changed config replaces the connection
+func (this *Source) connect(entity model.WatchedEntity, config Config) *connection {
+	key := entity.UserId + "/" + entity.Id
+	existing, ok := this.connections[key]
+	if ok && existing.rawConfig == string(entity.SourceConfig) {
+		return existing
+	}
+	if ok && existing.cancel != nil {
+		existing.cancel()
+	}
+	conn := &connection{userId: entity.UserId, id: entity.Id, rawConfig: string(entity.SourceConfig), config: config}
+	this.connections[key] = conn
+	this.run(conn)
+	return conn
+}
+
+// Release implements source.ReleasingSource
+func (this *Source) Release(userId string, id string) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	key := userId + "/" + id
+	if conn, ok := this.connections[key]; ok {
+		if conn.cancel != nil {
+			conn.cancel()
+		}
+		delete(this.connections, key)
+	}
+}
+
+// run keeps the connection open until it is released, if Start was called
+func (this *Source) run(conn *connection) {
+	if this.ctx == nil || conn.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(this.ctx)
+	conn.cancel = cancel
+	if this.wg != nil {
+		this.wg.Add(1)
+	}
+	go func() {
+		defer func() {
+			cancel()
+			if this.wg != nil {
+				this.wg.Done()
+			}
+		}()
+		backoff := this.reconnectBackoff
+		for {
+			connected, err := this.stream(ctx, conn)
+			if ctx.Err() != nil {
+				return
+			}
+			if connected {
+				backoff = this.reconnectBackoff
+			}
+			this.config.GetLogger().Warn("WARNING: sse connection closed --> reconnect", "watcher", conn.id, "backoff", backoff.String(), "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, this.maxReconnectBackoff)
+		}
+	}()
+}
+
+// stream reads events until the connection is closed; connected is true if the server accepted the request
+func (this *Source) stream(ctx context.Context, conn *connection) (connected bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, conn.config.Endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	for key, value := range conn.config.Header {
+		req.Header[key] = value
+	}
+	if conn.config.Format != FORMAT_LINES {
+		req.Header.Set("Accept", "text/event-stream")
+		if conn.lastEventId != "" {
+			req.Header.Set("Last-Event-ID", conn.lastEventId)
+		}
+	}
+	if conn.config.AddAuthToken {
+		token, err := this.auth.ExchangeUserToken(conn.userId)
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Authorization", token.Jwt())
+	}
+	resp, err := this.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		payload, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return false, fmt.Errorf("unexpected sse response: %v, %v", resp.StatusCode, string(payload))
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 4096), this.maxEventSize)
+	if conn.config.Format == FORMAT_LINES {
+		err = this.readLines(scanner, conn)
+	} else {
+		err = this.readEvents(scanner, conn)
+	}
+	if err == nil {
+		err = errors.New("stream closed by server")
+	}
+	return true, err
+}
+
+func (this *Source) readLines(scanner *bufio.Scanner, conn *connection) error {
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !this.handle(conn, []byte(line)) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// readEvents parses text/event-stream: events are separated by empty lines, the data lines of an event are joined by "\n"
+func (this *Source) readEvents(scanner *bufio.Scanner, conn *connection) error {
+	eventType := ""
+	data := []string{}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if eventType == "" {
+				eventType = "message"
+			}
+			if len(data) > 0 && (conn.config.Event == "" || conn.config.Event == eventType) {
+				if !this.handle(conn, []byte(strings.Join(data, "\n"))) {
+					return nil
+				}
+			}
+			eventType = ""
+			data = data[:0]
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue //comment, e.g. keep-alive
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			conn.lastEventId = value
+		}
+	}
+	return scanner.Err()
+}
+
+// handle stores the event as payload of the connection and requests the check of the entity;
+// returns false if the connection is no longer used
+func (this *Source) handle(conn *connection, payload []byte) bool {
+	this.mux.Lock()
+	if this.connections[conn.userId+"/"+conn.id] != conn {
+		this.mux.Unlock()
+		return false
+	}
+	conn.received = true
+	conn.payload = payload
+	this.mux.Unlock()
+	return this.notify(conn)
+}
+
+// notify requests the check of the entity; the check of a leased entity is retried after leaseRetryWait.
+// returns false if the entity no longer exists
+func (this *Source) notify(conn *connection) bool {
+	_, err := this.notifier.Notify(conn.userId, conn.id)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		this.Release(conn.userId, conn.id)
+		return false
+	case errors.Is(err, db.ErrLeased):
+		time.AfterFunc(leaseRetryWait, func() {
+			this.mux.Lock()
+			current := this.ctx.Err() == nil && this.connections[conn.userId+"/"+conn.id] == conn
+			this.mux.Unlock()
+			if current {
+				this.notify(conn)
+			}
+		})
+	case err != nil:
+		this.config.GetLogger().Error("ERROR: unable to check watcher after sse event", "watcher", conn.id, "error", err)
+	}
+	return true
+}
@@ -18,19 +18,26 @@ package trigger
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/auth"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/ratelimit"
 	"io"
 	"net/http"
 	"time"
 )
 
+// maxErrorPayloadSize limits the response body in the errors of unaccepted status codes, which are stored as LastError of pending triggers
+const maxErrorPayloadSize = 1024
+
 type Trigger struct {
 	auth           Auth
 	client         *http.Client
 	isolatedClient *http.Client
+	maxTimeout     time.Duration
+	limiter        *ratelimit.Limiter
 }
 
 type Auth interface {
@@ -38,12 +45,22 @@ type Auth interface {
 }
 
 func New(config configuration.Config, auth Auth) (*Trigger, error) {
+	limiter, err := ratelimit.New(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithLimiter(config, auth, limiter)
+}
+
+// NewWithLimiter uses limiter for all trigger requests, e.g. to share the limits of a host with the checker
+func NewWithLimiter(config configuration.Config, auth Auth, limiter *ratelimit.Limiter) (*Trigger, error) {
+	maxTimeout := config.GetMaxRequestTimeout()
 	return &Trigger{
-		auth: auth,
-		client: &http.Client{
-			Timeout: 5 * time.Second,
-		},
+		auth:           auth,
+		client:         &http.Client{Timeout: maxTimeout}, //shorter timeouts are set per request, see model.HttpRequest.GetTimeout
 		isolatedClient: config.GetSaveHttpClient(),
+		maxTimeout:     maxTimeout,
+		limiter:        limiter,
 	}, nil
 }
 
@@ -62,18 +79,31 @@ func (this *Trigger) Run(userId string, trigger model.HttpRequest) error {
 		}
 		req.Header.Set("Authorization", token.Jwt())
 	}
+	timeout, err := trigger.GetTimeout()
+	if err != nil {
+		return err
+	}
+	timeout = min(timeout, this.maxTimeout)
 	var client *http.Client
 	if trigger.Isolated {
 		client = this.isolatedClient
 	} else {
 		client = this.client
 	}
-	resp, err := client.Do(req)
+	//the timeout includes the delay of throttled requests
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err = this.limiter.Wait(ctx, trigger.Endpoint)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return err
 	}
-	if resp.StatusCode >= 300 {
-		temp, _ := io.ReadAll(resp.Body)
+	defer resp.Body.Close()
+	if !trigger.IsAccepted(resp.StatusCode) {
+		temp, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorPayloadSize))
 		return fmt.Errorf("unexpected trigger response: %v, %v", resp.StatusCode, string(temp))
 	}
 	return nil
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watcher
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/google/uuid"
+)
+
+// enqueueTrigger stores a failed (rendered) trigger of the entity for later retries and promotes the pending hash in the same transaction.
+// only an error of the queue itself is returned, because the trigger is no longer lost
+func (this *Watcher) enqueueTrigger(entity model.WatchedEntity, request model.HttpRequest, result model.CheckResult, triggerErr error) error {
+	trigger := model.PendingTrigger{
+		Id:        uuid.NewString(),
+		WatcherId: entity.Id,
+		UserId:    entity.UserId,
+		Trigger:   request,
+		CreatedAt: time.Now(),
+	}
+	this.setTriggerFailure(&trigger, triggerErr)
+	err := this.db.PromotePendingHash(entity.Id, entity.UserId, result, &trigger)
+	if err != nil {
+		return errors.Join(triggerErr, err)
+	}
+	this.config.GetLogger().Warn("WARNING: trigger failed --> queued for retry", "error", triggerErr, "watcherId", entity.Id, "userId", entity.UserId, "triggerId", trigger.Id, "deadLetter", trigger.DeadLetter)
+	return nil
+}
+
+// setTriggerFailure counts the failed attempt and schedules the next one; after config.TriggerMaxAttempts the trigger is dead-lettered
+func (this *Watcher) setTriggerFailure(trigger *model.PendingTrigger, triggerErr error) {
+	trigger.Attempts = trigger.Attempts + 1
+	trigger.LastError = storedError(triggerErr)
+	if trigger.Attempts >= this.getTriggerMaxAttempts() {
+		trigger.DeadLetter = true
+		return
+	}
+	trigger.NextAttempt = time.Now().Add(exponentialBackoff(this.triggerBackoff, this.maxTriggerBackoff, trigger.Attempts-1)).Unix()
+}
+
+func (this *Watcher) getTriggerMaxAttempts() int {
+	if this.config.TriggerMaxAttempts <= 0 {
+		return defaultTriggerMaxAttempts
+	}
+	return this.config.TriggerMaxAttempts
+}
+
+// RunPendingTriggers retries up to batchSize due pending triggers.
+// errors of all triggers are returned joined
+func (this *Watcher) RunPendingTriggers(batchSize int64) (count int, err error) {
+	list, err := this.db.FetchPendingTriggers(batchSize)
+	if err != nil {
+		return 0, err
+	}
+	errs := []error{}
+	for _, trigger := range list {
+		temperr := this.runPendingTrigger(trigger)
+		if temperr != nil {
+			this.config.GetLogger().Error("ERROR: unable to process pending trigger", "error", temperr, "triggerId", trigger.Id, "watcherId", trigger.WatcherId, "userId", trigger.UserId)
+			errs = append(errs, temperr)
+		}
+	}
+	return len(list), errors.Join(errs...)
+}
+
+func (this *Watcher) runPendingTrigger(trigger model.PendingTrigger) error {
+	err := this.trigger.Run(trigger.UserId, trigger.Trigger)
+	if err == nil {
+		return this.db.RemovePendingTrigger(trigger.Id)
+	}
+	this.setTriggerFailure(&trigger, err)
+	if trigger.DeadLetter {
+		this.config.GetLogger().Error("ERROR: trigger dead-lettered", "error", err, "triggerId", trigger.Id, "watcherId", trigger.WatcherId, "userId", trigger.UserId, "attempts", trigger.Attempts)
+	}
+	return this.db.UpdatePendingTrigger(trigger)
+}
+
+func (this *Watcher) ListDeadLetters(userId string) (result []model.PendingTrigger, err error) {
+	result, err = this.db.ListDeadLetters(userId)
+	if err != nil {
+		return result, err
+	}
+	for i, trigger := range result {
+		trigger.Trigger.Header = model.RedactHeader(trigger.Trigger.Header)
+		result[i] = trigger
+	}
+	return result, nil
+}
+
+// ReplayDeadLetter resets the attempts of the dead-lettered trigger, so that it is retried with the next cycle
+func (this *Watcher) ReplayDeadLetter(userId string, triggerId string) error {
+	trigger, err := this.db.ReadPendingTrigger(triggerId, userId)
+	if err != nil {
+		return err
+	}
+	if !trigger.DeadLetter {
+		return ErrNotDeadLettered
+	}
+	trigger.DeadLetter = false
+	trigger.Attempts = 0
+	trigger.NextAttempt = 0
+	return this.db.UpdatePendingTrigger(trigger)
+}
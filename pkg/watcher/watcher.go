@@ -18,24 +18,75 @@ package watcher
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/google/uuid"
 )
 
 type Watcher struct {
-	config         configuration.Config
-	db             db.Database
-	checker        Checker
-	trigger        Trigger
-	cleanupChecker CleanupChecker
+	config            configuration.Config
+	instanceId        string
+	db                db.Database
+	checker           Checker
+	trigger           Trigger
+	cleanupChecker    CleanupChecker
+	maxFailureBackoff time.Duration
+	triggerBackoff    time.Duration
+	maxTriggerBackoff time.Duration
+}
+
+// ErrCheckFailed marks errors of the Checker; only these count as failures of the watched entity
+var ErrCheckFailed = errors.New("check failed")
+
+var ErrNotDeadLettered = errors.New("trigger is not dead-lettered")
+
+const defaultMaxFailureBackoff = 24 * time.Hour
+const defaultTriggerBackoff = 10 * time.Second
+const defaultMaxTriggerBackoff = 30 * time.Minute
+const defaultTriggerMaxAttempts = 10
+const defaultMaxConcurrentChecks = 10
+
+// maxStoredErrorLength limits the error messages persisted as LastError and in the history,
+// errors of sources or custom checkers may contain arbitrarily large payloads
+const maxStoredErrorLength = 2048
+
+func storedError(err error) string {
+	msg := err.Error()
+	if len(msg) <= maxStoredErrorLength {
+		return msg
+	}
+	cut := maxStoredErrorLength
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+	return msg[:cut] + "..."
 }
 
 type Checker interface {
-	Check(userId string, request model.HttpRequest, hashType string, lastHash string) (changed bool, newHash string, err error)
+	CheckEntity(entity model.WatchedEntity) (result model.CheckResult, err error)
+}
+
+// EntityValidator may be implemented by a Checker to reject entities it is unable to check
+type EntityValidator interface {
+	Validate(entity model.WatchedEntityInit) error
+}
+
+// EntityReleaser may be implemented by a Checker to release resources (e.g. connections) of deleted entities
+type EntityReleaser interface {
+	Release(userId string, id string)
+}
+
+// SourceConfigRedactor may be implemented by a Checker to redact credentials in the SourceConfig of returned entities
+type SourceConfigRedactor interface {
+	RedactSourceConfig(entity model.WatchedEntityInit) json.RawMessage
 }
 
 type Trigger interface {
@@ -47,16 +98,30 @@ type CleanupChecker interface {
 }
 
 func New(config configuration.Config, db db.Database, check Checker, trigger Trigger, cleanupChecker CleanupChecker) *Watcher {
+	instanceId := config.InstanceId
+	if instanceId == "" {
+		instanceId = uuid.NewString()
+	}
 	return &Watcher{
-		config:         config,
-		db:             db,
-		checker:        check,
-		trigger:        trigger,
-		cleanupChecker: cleanupChecker,
+		config:            config,
+		instanceId:        instanceId,
+		db:                db,
+		checker:           check,
+		trigger:           trigger,
+		cleanupChecker:    cleanupChecker,
+		maxFailureBackoff: config.ParseDuration("max_failure_backoff", config.MaxFailureBackoff, defaultMaxFailureBackoff),
+		triggerBackoff:    config.ParseDuration("trigger_retry_backoff", config.TriggerRetryBackoff, defaultTriggerBackoff),
+		maxTriggerBackoff: config.ParseDuration("trigger_max_retry_backoff", config.TriggerMaxRetryBackoff, defaultMaxTriggerBackoff),
 	}
 }
 
 func (this *Watcher) Set(entity model.WatchedEntityInit) error {
+	if validator, ok := this.checker.(EntityValidator); ok {
+		err := validator.Validate(entity)
+		if err != nil {
+			return err
+		}
+	}
 	return this.db.Set(entity)
 }
 
@@ -94,6 +159,10 @@ func (this *Watcher) StartWithInterval(ctx context.Context, wg *sync.WaitGroup,
 				if err != nil {
 					this.config.GetLogger().Error("ERROR: Watcher::StartWithInterval::Run()", "error", err)
 				}
+				_, err = this.RunPendingTriggers(this.config.BatchSize)
+				if err != nil {
+					this.config.GetLogger().Error("ERROR: Watcher::StartWithInterval::RunPendingTriggers()", "error", err)
+				}
 			}
 		}
 	}()
@@ -117,54 +186,297 @@ func (this *Watcher) RunLoop(ctx context.Context, batchSize int64) error {
 	}
 }
 
+// Run fetches up to batchSize due entities and processes them with at most config.MaxConcurrentChecks parallel goroutines.
+// errors of all entities are returned joined
 func (this *Watcher) Run(batchSize int64) (count int, err error) {
-	list, err := this.db.Fetch(batchSize)
+	list, err := this.db.Fetch(this.instanceId, batchSize)
 	if err != nil {
 		return 0, err
 	}
+	mux := sync.Mutex{}
+	errs := []error{}
 	wg := sync.WaitGroup{}
+	limiter := make(chan struct{}, this.getMaxConcurrentChecks())
 	for _, entity := range list {
 		wg.Add(1)
+		limiter <- struct{}{}
 		go func(entity model.WatchedEntity) {
-			defer wg.Done()
-			remove, temperr := this.cleanupChecker.Check(entity)
-			if temperr != nil {
-				err = temperr
-				return
-			}
-			if remove {
-				temperr = this.db.Delete(entity.Id, entity.UserId)
-				if temperr != nil {
-					err = temperr
-					return
-				}
-				return
-			}
-			chenged, newHash, temperr := this.checker.Check(entity.UserId, entity.Watch, entity.HashType, entity.LastHash)
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			temperr := this.runEntity(entity)
 			if temperr != nil {
-				err = temperr
-				return
-			}
-			if chenged {
-				temperr = this.db.UpdateHash(entity.Id, entity.UserId, newHash)
-				if temperr != nil {
-					err = temperr
-					return
-				}
-				if entity.LastHash != "" {
-					temperr = this.trigger.Run(entity.UserId, entity.Trigger)
-					if temperr != nil {
-						err = temperr
-						return
-					}
-				}
+				this.config.GetLogger().Error("ERROR: unable to process watched entity", "error", temperr, "watcherId", entity.Id, "userId", entity.UserId)
+				mux.Lock()
+				defer mux.Unlock()
+				errs = append(errs, fmt.Errorf("watcher %v of user %v: %w", entity.Id, entity.UserId, temperr))
 			}
 		}(entity)
 	}
 	wg.Wait()
-	return len(list), err
+	return len(list), errors.Join(errs...)
+}
+
+// getMaxConcurrentChecks defaults to the max_concurrent_checks of config.json, if the value is missing or not positive
+func (this *Watcher) getMaxConcurrentChecks() int {
+	if this.config.MaxConcurrentChecks <= 0 {
+		return defaultMaxConcurrentChecks
+	}
+	return this.config.MaxConcurrentChecks
+}
+
+func (this *Watcher) runEntity(entity model.WatchedEntity) (err error) {
+	defer func() {
+		err = errors.Join(err, this.db.ReleaseLease(this.instanceId, entity.Id, entity.UserId))
+	}()
+	remove, err := this.cleanupChecker.Check(entity)
+	if err != nil {
+		return err
+	}
+	if remove {
+		return this.delete(entity.UserId, entity.Id)
+	}
+	return this.checkAndRecordFailures(entity)
+}
+
+// checkAndRecordFailures checks the entity and updates its failure state
+func (this *Watcher) checkAndRecordFailures(entity model.WatchedEntity) error {
+	_, _, err := this.checkEntityWithHistory(entity)
+	if errors.Is(err, ErrCheckFailed) {
+		return errors.Join(err, this.recordFailure(entity, err))
+	}
+	if err == nil && entity.ConsecutiveFailures > 0 {
+		return this.db.UpdateFailureState(entity.Id, entity.UserId, model.FailureState{}, entity.TimestampOfNextCheck)
+	}
+	return err
+}
+
+// recordFailure increments the failure count of the entity and pushes its next check out by exponential backoff.
+// the entity is disabled if config.MaxConsecutiveFailures > 0 is reached
+func (this *Watcher) recordFailure(entity model.WatchedEntity, checkErr error) error {
+	state := model.FailureState{
+		ConsecutiveFailures: entity.ConsecutiveFailures + 1,
+		LastError:           storedError(checkErr),
+	}
+	if this.config.MaxConsecutiveFailures > 0 && state.ConsecutiveFailures >= this.config.MaxConsecutiveFailures {
+		state.Disabled = true
+		this.config.GetLogger().Warn("WARNING: disable watcher after consecutive check failures", "watcherId", entity.Id, "userId", entity.UserId, "failures", state.ConsecutiveFailures)
+	}
+	nextCheck := time.Now().Add(this.getFailureBackoff(entity.Interval, state.ConsecutiveFailures)).Unix()
+	return this.db.UpdateFailureState(entity.Id, entity.UserId, state, nextCheck)
+}
+
+// getFailureBackoff returns interval * 2^failures, capped by config.MaxFailureBackoff but at least interval
+func (this *Watcher) getFailureBackoff(interval string, failures int) time.Duration {
+	base, err := time.ParseDuration(interval)
+	if err != nil || base <= 0 {
+		base = time.Hour
+	}
+	return exponentialBackoff(base, this.maxFailureBackoff, failures)
+}
+
+// exponentialBackoff returns base * 2^exponent, capped by limit but at least base
+func exponentialBackoff(base time.Duration, limit time.Duration, exponent int) time.Duration {
+	if base >= limit {
+		return base
+	}
+	result := base
+	for i := 0; i < exponent && result < limit; i++ {
+		result = result * 2
+	}
+	return min(result, limit)
+}
+
+func (this *Watcher) checkEntityWithHistory(entity model.WatchedEntity) (result model.CheckResult, triggered bool, err error) {
+	start := time.Now()
+	result, triggered, err = this.checkEntity(entity)
+	this.addHistoryEntry(model.HistoryEntry{
+		WatcherId:  entity.Id,
+		UserId:     entity.UserId,
+		Timestamp:  start,
+		DurationMs: time.Since(start).Milliseconds(),
+		StatusCode: result.StatusCode,
+		OldHash:    entity.LastHash,
+		NewHash:    result.Hash,
+		Triggered:  triggered,
+	}, err)
+	return result, triggered, err
+}
+
+// CheckNow checks the watcher of the user immediately, independent of its interval and paused state.
+// the check claims the lease of the watcher, so db.ErrLeased is returned while another check of the watcher is running.
+// a dry run only computes the hash, without storing it or running the trigger
+func (this *Watcher) CheckNow(userId string, watcherId string, dryRun bool) (result model.ManualCheckResult, err error) {
+	result.DryRun = dryRun
+	if dryRun {
+		entity, err := this.db.Read(watcherId, userId)
+		if err != nil {
+			return result, err
+		}
+		result.CheckResult, err = this.checker.CheckEntity(entity)
+		return result, err
+	}
+	entity, err := this.db.ClaimLease(this.instanceId, watcherId, userId)
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		err = errors.Join(err, this.db.ReleaseLease(this.instanceId, entity.Id, entity.UserId))
+	}()
+	result.CheckResult, result.Triggered, err = this.checkEntityWithHistory(entity)
+	return result, err
+}
+
+// Notify checks the watcher after a push source received a possible change. unlike CheckNow, paused and disabled watchers
+// are skipped (checked is false) and failures count like failures of scheduled checks.
+// db.ErrLeased is returned while another check of the watcher is running
+func (this *Watcher) Notify(userId string, watcherId string) (checked bool, err error) {
+	entity, err := this.db.ClaimLease(this.instanceId, watcherId, userId)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		err = errors.Join(err, this.db.ReleaseLease(this.instanceId, entity.Id, entity.UserId))
+	}()
+	if entity.Paused || entity.Disabled {
+		return false, nil
+	}
+	return true, this.checkAndRecordFailures(entity)
+}
+
+// ListBySourceType returns the watchers of all users with the source type
+func (this *Watcher) ListBySourceType(sourceType string) ([]model.WatchedEntity, error) {
+	return this.db.ListBySourceType(sourceType)
+}
+
+// checkEntity stores a changed hash as pending and promotes it to LastHash only after the trigger succeeded
+// or was handed to the pending trigger queue. if a step fails, LastHash is unchanged and the next check triggers again (at-least-once).
+func (this *Watcher) checkEntity(entity model.WatchedEntity) (result model.CheckResult, triggered bool, err error) {
+	checkTime := time.Now()
+	result, err = this.checker.CheckEntity(entity)
+	if err != nil {
+		return result, false, fmt.Errorf("%w: %w", ErrCheckFailed, err)
+	}
+	if !result.Changed {
+		if result.ETag != entity.ETag || result.LastModified != entity.LastModified {
+			//same content with new validators
+			return result, false, this.db.UpdateValidators(entity.Id, entity.UserId, result.ETag, result.LastModified)
+		}
+		return result, false, nil
+	}
+	err = this.db.SetPendingHash(entity.Id, entity.UserId, result.Hash)
+	if err != nil {
+		return result, false, err
+	}
+	//the initial hash is not triggered, except for a true predicate: the unknown state before the first check counts as false
+	initial := entity.LastHash == "" && result.Predicate == nil
+	if initial || !matchesTriggerCondition(entity, result) {
+		//initial hash or change not matching the trigger condition: nothing to trigger
+		return result, false, this.db.PromotePendingHash(entity.Id, entity.UserId, result, nil)
+	}
+	trigger, err := renderTrigger(entity, result, checkTime)
+	if err != nil {
+		//the same result fails on every check, so it counts like a failed check
+		return result, false, fmt.Errorf("%w: %w", ErrCheckFailed, err)
+	}
+	err = this.trigger.Run(entity.UserId, trigger)
+	if err != nil {
+		return result, false, this.enqueueTrigger(entity, trigger, result, err)
+	}
+	return result, true, this.db.PromotePendingHash(entity.Id, entity.UserId, result, nil)
+}
+
+// addHistoryEntry stores the entry if config.HistoryRetention > 0
+// failures are only logged to not interfere with the check itself
+func (this *Watcher) addHistoryEntry(entry model.HistoryEntry, checkErr error) {
+	if this.config.HistoryRetention <= 0 {
+		return
+	}
+	if checkErr != nil {
+		entry.Error = storedError(checkErr)
+	}
+	err := this.db.AddHistoryEntry(entry)
+	if err != nil {
+		this.config.GetLogger().Error("ERROR: unable to store history entry", "error", err, "watcherId", entry.WatcherId, "userId", entry.UserId)
+	}
+}
+
+func (this *Watcher) GetHistory(userId string, watcherId string, limit int64) (result []model.HistoryEntry, err error) {
+	_, err = this.db.Read(watcherId, userId)
+	if err != nil {
+		return result, err
+	}
+	return this.db.ListHistory(watcherId, userId, limit)
+}
+
+// ListWatchers returns the watchers of the user with redacted header values
+func (this *Watcher) ListWatchers(userId string, query db.QueryOptions) (result []model.WatchedEntity, err error) {
+	result, err = this.db.ListByUser(userId, query)
+	if err != nil {
+		return result, err
+	}
+	for i, entity := range result {
+		result[i] = this.redact(entity)
+	}
+	return result, nil
+}
+
+// GetWatcher returns the watcher of the user with redacted header values
+func (this *Watcher) GetWatcher(userId string, watcherId string) (result model.WatchedEntity, err error) {
+	result, err = this.db.Read(watcherId, userId)
+	if err != nil {
+		return result, err
+	}
+	return this.redact(result), nil
+}
+
+const RedactedValue = model.RedactedValue
+
+// redact replaces all header values of the watch and trigger request and credentials in the source config, because they may contain credentials
+func (this *Watcher) redact(entity model.WatchedEntity) model.WatchedEntity {
+	entity.Watch.Header = model.RedactHeader(entity.Watch.Header)
+	entity.Trigger.Header = model.RedactHeader(entity.Trigger.Header)
+	if redactor, ok := this.checker.(SourceConfigRedactor); ok {
+		entity.SourceConfig = redactor.RedactSourceConfig(entity.WatchedEntityInit)
+	}
+	return entity
+}
+
+// PauseWatcher stops the checks of the watcher until ResumeWatcher is called; the last hash is kept,
+// so that a change made while the watcher is paused triggers after it is resumed
+func (this *Watcher) PauseWatcher(userId string, watcherId string) (err error) {
+	return this.db.SetPaused(watcherId, userId, true)
+}
+
+// ResumeWatcher resumes a paused watcher; a watcher disabled by consecutive check failures
+// is re-enabled and checked with the next cycle
+func (this *Watcher) ResumeWatcher(userId string, watcherId string) (err error) {
+	err = this.db.SetPaused(watcherId, userId, false)
+	if err != nil {
+		return err
+	}
+	entity, err := this.db.Read(watcherId, userId)
+	if err != nil {
+		return err
+	}
+	if !entity.Disabled && entity.ConsecutiveFailures == 0 {
+		return nil
+	}
+	return this.db.UpdateFailureState(watcherId, userId, model.FailureState{}, 0)
 }
 
 func (this *Watcher) DeleteWatcher(userId string, watcherId string) (err error) {
-	return this.db.Delete(watcherId, userId)
+	return this.delete(userId, watcherId)
+}
+
+func (this *Watcher) delete(userId string, watcherId string) error {
+	err := this.db.Delete(watcherId, userId)
+	if err != nil {
+		return err
+	}
+	if releaser, ok := this.checker.(EntityReleaser); ok {
+		releaser.Release(userId, watcherId)
+	}
+	return nil
 }
@@ -21,7 +21,14 @@ import (
 	"errors"
 	"fmt"
 	lib_model "github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/jsonpath"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/predicate"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/schedule"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -87,6 +94,44 @@ func (this *Worker) getWatchInterval(task lib_model.CamundaExternalTask) (result
 	return result
 }
 
+// scheduleSampleSize is the number of scheduled checks compared with min_watch_interval
+const scheduleSampleSize = 1000
+
+// getWatchSchedule reads the cron expression that replaces watch_interval, e.g. "CRON_TZ=Europe/Berlin 0 6 * * 1-5".
+// schedules with consecutive checks closer than min_watch_interval are rejected.
+func (this *Worker) getWatchSchedule(task lib_model.CamundaExternalTask) (string, error) {
+	variable, ok := task.Variables[this.config.WorkerParamPrefix+"watch_schedule"]
+	if !ok {
+		return "", nil
+	}
+	str, ok := variable.Value.(string)
+	if !ok {
+		return "", errors.New("expect watch_schedule as string")
+	}
+	if str == "" {
+		return "", nil
+	}
+	s, err := schedule.Parse(str)
+	if err != nil {
+		return "", err
+	}
+	previous := s.Next(time.Now())
+	if previous.IsZero() {
+		return "", fmt.Errorf("watch_schedule %#v has no reachable date", str)
+	}
+	for range scheduleSampleSize {
+		next := s.Next(previous)
+		if next.IsZero() {
+			break
+		}
+		if next.Sub(previous) < this.minWatchInterval {
+			return "", fmt.Errorf("watch_schedule %#v checks more often than min_watch_interval %v", str, this.minWatchInterval)
+		}
+		previous = next
+	}
+	return str, nil
+}
+
 func (this *Worker) getHashType(task lib_model.CamundaExternalTask) string {
 	variable, ok := task.Variables[this.config.WorkerParamPrefix+"hash_type"]
 	if !ok {
@@ -99,6 +144,236 @@ func (this *Worker) getHashType(task lib_model.CamundaExternalTask) string {
 	return str
 }
 
+// getHashPaths reads the json path expressions used by the jsonpath hash type, which requires at least one expression.
+// the variable may contain a json encoded list of expressions or a single expression.
+func (this *Worker) getHashPaths(task lib_model.CamundaExternalTask, hashType string) (result []string, err error) {
+	result, err = this.getStringListVariable(task, "hash_paths", func(expression string) error {
+		_, err := jsonpath.Parse(expression)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 && hashType == checker.HASH_TYPE_JSONPATH {
+		return nil, fmt.Errorf("hash_type %v requires hash_paths", checker.HASH_TYPE_JSONPATH)
+	}
+	return result, nil
+}
+
+// getHashIgnoreFields reads the field names and json pointers removed from the watched response before hashing.
+// the variable may contain a json encoded list or a single entry.
+func (this *Worker) getHashIgnoreFields(task lib_model.CamundaExternalTask) (result []string, err error) {
+	return this.getStringListVariable(task, "hash_ignore_fields", checker.ValidateIgnoreField)
+}
+
+// getStringListVariable reads a variable containing a json encoded list of strings or a single string.
+// every element is checked by validate.
+func (this *Worker) getStringListVariable(task lib_model.CamundaExternalTask, name string, validate func(string) error) (result []string, err error) {
+	variable, ok := task.Variables[this.config.WorkerParamPrefix+name]
+	if !ok {
+		return nil, nil
+	}
+	switch v := variable.Value.(type) {
+	case string:
+		if strings.HasPrefix(strings.TrimSpace(v), "[") {
+			err = json.Unmarshal([]byte(v), &result)
+			if err != nil {
+				return nil, fmt.Errorf("expect %v as json encoded list of strings: %w", name, err)
+			}
+		} else if v != "" {
+			result = []string{v}
+		}
+	case []interface{}:
+		for _, element := range v {
+			str, ok := element.(string)
+			if !ok {
+				return nil, fmt.Errorf("expect %v as list of strings", name)
+			}
+			result = append(result, str)
+		}
+	default:
+		return nil, fmt.Errorf("expect %v as json encoded list of strings", name)
+	}
+	for _, element := range result {
+		err = validate(element)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// getPredicate reads the boolean expression of the predicate hash type, which is required for this hash type
+func (this *Worker) getPredicate(task lib_model.CamundaExternalTask, hashType string) (string, error) {
+	str := ""
+	variable, ok := task.Variables[this.config.WorkerParamPrefix+"predicate"]
+	if ok {
+		str, ok = variable.Value.(string)
+		if !ok {
+			return "", errors.New("expect predicate as string")
+		}
+	}
+	if str == "" {
+		if hashType == checker.HASH_TYPE_PREDICATE {
+			return "", fmt.Errorf("hash_type %v requires a predicate", checker.HASH_TYPE_PREDICATE)
+		}
+		return "", nil
+	}
+	_, err := predicate.Parse(str)
+	if err != nil {
+		return "", err
+	}
+	return str, nil
+}
+
+func (this *Worker) getPredicateMode(task lib_model.CamundaExternalTask) (string, error) {
+	variable, ok := task.Variables[this.config.WorkerParamPrefix+"predicate_mode"]
+	if !ok {
+		return "", nil
+	}
+	str, ok := variable.Value.(string)
+	if !ok {
+		return "", errors.New("expect predicate_mode as string")
+	}
+	switch str {
+	case "", checker.PREDICATE_MODE_EDGE, checker.PREDICATE_MODE_LEVEL:
+		return str, nil
+	default:
+		return "", fmt.Errorf("unknown predicate_mode %#v", str)
+	}
+}
+
+// getTriggerCondition reads the condition limiting the changes that run the trigger.
+// the device conditions are only valid for the deviceids hash type.
+func (this *Worker) getTriggerCondition(task lib_model.CamundaExternalTask, hashType string) (string, error) {
+	variable, ok := task.Variables[this.config.WorkerParamPrefix+"trigger_condition"]
+	if !ok {
+		return "", nil
+	}
+	str, ok := variable.Value.(string)
+	if !ok {
+		return "", errors.New("expect trigger_condition as string")
+	}
+	if !slices.Contains(watcher.TriggerConditions, str) {
+		return "", fmt.Errorf("unknown trigger_condition %#v", str)
+	}
+	if str != "" && hashType != checker.HASH_TYPE_DEVICEIDS {
+		return "", fmt.Errorf("trigger_condition %#v requires hash_type %v", str, checker.HASH_TYPE_DEVICEIDS)
+	}
+	return str, nil
+}
+
+// getTriggerInputs reads the trigger_inputs.<name> variables, mapping values known at trigger time (see watcher.TriggerInputNames)
+// to the maintenance procedure input ids they are passed to
+func (this *Worker) getTriggerInputs(task lib_model.CamundaExternalTask) (result map[string]string, err error) {
+	prefix := this.config.WorkerParamPrefix + "trigger_inputs."
+	for key, variable := range task.Variables {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, prefix)
+		if !slices.Contains(watcher.TriggerInputNames, name) {
+			return nil, fmt.Errorf("unknown trigger input %#v", name)
+		}
+		inputId, ok := variable.Value.(string)
+		if !ok || inputId == "" {
+			return nil, fmt.Errorf("expect trigger_inputs.%v as maintenance procedure input id", name)
+		}
+		if result == nil {
+			result = map[string]string{}
+		}
+		result[name] = inputId
+	}
+	return result, nil
+}
+
+// getTriggerResponsePath reads the json path expression selecting the part of the watched response passed as response trigger input
+func (this *Worker) getTriggerResponsePath(task lib_model.CamundaExternalTask) (string, error) {
+	variable, ok := task.Variables[this.config.WorkerParamPrefix+"trigger_response_path"]
+	if !ok {
+		return "", nil
+	}
+	str, ok := variable.Value.(string)
+	if !ok {
+		return "", errors.New("expect trigger_response_path as string")
+	}
+	if str == "" {
+		return "", nil
+	}
+	_, err := jsonpath.Parse(str)
+	if err != nil {
+		return "", err
+	}
+	return str, nil
+}
+
+func (this *Worker) getStartPaused(task lib_model.CamundaExternalTask) bool {
+	return this.getBoolVariable(task, "start_paused")
+}
+
+func (this *Worker) getHashSortArrays(task lib_model.CamundaExternalTask) bool {
+	return this.getBoolVariable(task, "hash_sort_arrays")
+}
+
+func (this *Worker) getHashStatusCode(task lib_model.CamundaExternalTask) bool {
+	return this.getBoolVariable(task, "hash_status_code")
+}
+
+// getBoolVariable reads a bool or a string parsable by strconv.ParseBool; missing or invalid values are false
+func (this *Worker) getBoolVariable(task lib_model.CamundaExternalTask, name string) bool {
+	variable, ok := task.Variables[this.config.WorkerParamPrefix+name]
+	if !ok {
+		return false
+	}
+	switch v := variable.Value.(type) {
+	case bool:
+		return v
+	case string:
+		result, _ := strconv.ParseBool(v)
+		return result
+	default:
+		return false
+	}
+}
+
+// selectWatchSource selects the http request like selectWatchedHttpRequest or, if none is given,
+// the config of another registered source type, given as json encoded "watch_<source_type>" variable
+func (this *Worker) selectWatchSource(task lib_model.CamundaExternalTask) (sourceType string, req model.HttpRequest, sourceConfig json.RawMessage, err error) {
+	req, err = this.selectWatchedHttpRequest(task)
+	if err == nil {
+		return model.SOURCE_TYPE_HTTP, req, nil, nil
+	}
+	if !errors.Is(err, MissingVariableUsage) {
+		return sourceType, req, nil, err
+	}
+	for _, sourceType = range this.sources.SourceTypes() {
+		if sourceType == model.SOURCE_TYPE_HTTP {
+			continue
+		}
+		sourceConfig, err = this.getWatchSourceConfig(task, sourceType)
+		if err == nil {
+			return sourceType, req, sourceConfig, nil
+		}
+		if !errors.Is(err, MissingVariableUsage) {
+			return sourceType, req, nil, err
+		}
+	}
+	return "", req, nil, fmt.Errorf("%w: no known watch request or source found", MissingVariableUsage)
+}
+
+func (this *Worker) getWatchSourceConfig(task lib_model.CamundaExternalTask, sourceType string) (json.RawMessage, error) {
+	varName := this.config.WorkerParamPrefix + "watch_" + sourceType
+	variable, ok := task.Variables[varName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", MissingVariableUsage, varName)
+	}
+	str, ok := variable.Value.(string)
+	if !ok || !json.Valid([]byte(str)) {
+		return nil, fmt.Errorf("expect %v as json encoded string", varName)
+	}
+	return json.RawMessage(str), nil
+}
+
 func (this *Worker) selectWatchedHttpRequest(task lib_model.CamundaExternalTask) (req model.HttpRequest, err error) {
 	selectables := []func(task lib_model.CamundaExternalTask) (req model.HttpRequest, err error){
 		this.getWatchedDevicesHttpRequest,
@@ -138,6 +413,18 @@ func (this *Worker) getWatchedHttpRequest(task lib_model.CamundaExternalTask) (r
 	if req.Header == nil {
 		req.Header = map[string][]string{}
 	}
+	timeout, err := req.GetTimeout()
+	if err != nil {
+		return req, fmt.Errorf("invalid watch_request timeout: %w", err)
+	}
+	if maxTimeout := this.config.GetMaxRequestTimeout(); timeout > maxTimeout {
+		return req, fmt.Errorf("invalid watch_request timeout: %v exceeds max_request_timeout %v", timeout, maxTimeout)
+	}
+	for _, code := range req.AcceptedStatusCodes {
+		if code < 100 || code > 599 {
+			return req, fmt.Errorf("invalid watch_request accepted_status_codes: %v", code)
+		}
+	}
 	req.Isolated = true
 	return req, nil
 }
@@ -28,9 +28,10 @@ import (
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source"
 )
 
-func New(config configuration.Config, libConfig libconfiguration.Config, auth *auth.Auth, smartServiceRepo SmartServiceRepo, w *watcher.Watcher) (*Worker, error) {
+func New(config configuration.Config, libConfig libconfiguration.Config, auth *auth.Auth, smartServiceRepo SmartServiceRepo, w *watcher.Watcher, sources *source.Registry) (*Worker, error) {
 	minWatchInterval, err := time.ParseDuration(config.MinWatchInterval)
 	if err != nil {
 		return nil, err
@@ -45,6 +46,7 @@ func New(config configuration.Config, libConfig libconfiguration.Config, auth *a
 		auth:                 auth,
 		smartServiceRepo:     smartServiceRepo,
 		watcher:              w,
+		sources:              sources,
 		defaultWatchInterval: defaultWatchInterval,
 		minWatchInterval:     minWatchInterval,
 	}, nil
@@ -56,6 +58,7 @@ type Worker struct {
 	auth                 *auth.Auth
 	smartServiceRepo     SmartServiceRepo
 	watcher              *watcher.Watcher
+	sources              *source.Registry
 	defaultWatchInterval time.Duration
 	minWatchInterval     time.Duration
 }
@@ -76,9 +79,58 @@ func (this *Worker) Do(task lib_model.CamundaExternalTask) (modules []lib_model.
 
 	id := this.getModuleId(task)
 	procedure := this.getMaintenanceProcedureEventName(task)
-	httpWatch, err := this.selectWatchedHttpRequest(task)
+	sourceType, httpWatch, sourceConfig, err := this.selectWatchSource(task)
 	if err != nil {
-		this.libConfig.GetLogger().Error("ERROR: unable to select watched http request parameter", "error", err)
+		this.libConfig.GetLogger().Error("ERROR: unable to select watch source parameter", "error", err)
+		return modules, outputs, err
+	}
+
+	watchSchedule, err := this.getWatchSchedule(task)
+	if err != nil {
+		this.libConfig.GetLogger().Error("ERROR: invalid watch_schedule parameter", "error", err)
+		return modules, outputs, err
+	}
+
+	hashType := this.getHashType(task)
+	hashPaths, err := this.getHashPaths(task, hashType)
+	if err != nil {
+		this.libConfig.GetLogger().Error("ERROR: invalid hash_paths parameter", "error", err)
+		return modules, outputs, err
+	}
+
+	hashIgnoreFields, err := this.getHashIgnoreFields(task)
+	if err != nil {
+		this.libConfig.GetLogger().Error("ERROR: invalid hash_ignore_fields parameter", "error", err)
+		return modules, outputs, err
+	}
+
+	triggerCondition, err := this.getTriggerCondition(task, hashType)
+	if err != nil {
+		this.libConfig.GetLogger().Error("ERROR: invalid trigger_condition parameter", "error", err)
+		return modules, outputs, err
+	}
+
+	predicateExpression, err := this.getPredicate(task, hashType)
+	if err != nil {
+		this.libConfig.GetLogger().Error("ERROR: invalid predicate parameter", "error", err)
+		return modules, outputs, err
+	}
+
+	predicateMode, err := this.getPredicateMode(task)
+	if err != nil {
+		this.libConfig.GetLogger().Error("ERROR: invalid predicate_mode parameter", "error", err)
+		return modules, outputs, err
+	}
+
+	triggerInputs, err := this.getTriggerInputs(task)
+	if err != nil {
+		this.libConfig.GetLogger().Error("ERROR: invalid trigger_inputs parameter", "error", err)
+		return modules, outputs, err
+	}
+
+	triggerResponsePath, err := this.getTriggerResponsePath(task)
+	if err != nil {
+		this.libConfig.GetLogger().Error("ERROR: invalid trigger_response_path parameter", "error", err)
 		return modules, outputs, err
 	}
 
@@ -89,18 +141,33 @@ func (this *Worker) Do(task lib_model.CamundaExternalTask) (modules []lib_model.
 	}
 
 	err = this.watcher.Set(model.WatchedEntityInit{
-		Id:       id,
-		UserId:   sm.UserId,
-		Interval: this.getWatchInterval(task).String(),
-		HashType: this.getHashType(task),
-		Watch:    httpWatch,
+		Id:           id,
+		UserId:       sm.UserId,
+		Interval:     this.getWatchInterval(task).String(),
+		Schedule:     watchSchedule,
+		HashType:     hashType,
+		SourceType:   sourceType,
+		SourceConfig: sourceConfig,
+		HashOptions: model.HashOptions{
+			HashPaths:        hashPaths,
+			HashSortArrays:   this.getHashSortArrays(task),
+			HashIgnoreFields: hashIgnoreFields,
+			Predicate:        predicateExpression,
+			PredicateMode:    predicateMode,
+			HashStatusCode:   this.getHashStatusCode(task),
+		},
+		Watch: httpWatch,
 		Trigger: model.HttpRequest{
 			Method:       "POST",
 			Endpoint:     this.libConfig.SmartServiceRepositoryUrl + "/instances/" + url.PathEscape(sm.Id) + "/maintenance-procedures/" + url.PathEscape(procedure) + "/start",
 			Body:         maintenanceProcedureInputs,
 			AddAuthToken: true,
 		},
-		CreatedAt: time.Now().Unix(),
+		CreatedAt:           time.Now().Unix(),
+		Paused:              this.getStartPaused(task),
+		TriggerCondition:    triggerCondition,
+		TriggerInputs:       triggerInputs,
+		TriggerResponsePath: triggerResponsePath,
 	})
 
 	if err != nil {
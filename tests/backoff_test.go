@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestFailureBackoff(t *testing.T) {
+	config := configuration.Config{
+		MaxFailureBackoff:      "3h",
+		MaxConsecutiveFailures: 3,
+	}
+	database, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = database.Set(model.WatchedEntityInit{
+		Id:       "w1",
+		UserId:   "user",
+		Interval: "1h",
+		Watch:    model.HttpRequest{Endpoint: "watch"},
+		Trigger:  model.HttpRequest{Endpoint: "trigger"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	c := mocks.NewCountingChecker()
+	c.Errors["watch"] = errors.New("503 service unavailable")
+	w := watcher.New(config, database, c, mocks.NewCountingTrigger(), mocks.CleanupChecker{})
+
+	//makes the entity due again without changing its failure state
+	makeDue := func(t *testing.T) {
+		e, err := database.Read("w1", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = database.UpdateFailureState("w1", "user", e.FailureState, 0)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	checkState := func(t *testing.T, failures int, disabled bool, backoff time.Duration) {
+		e, err := database.Read("w1", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.ConsecutiveFailures != failures || e.Disabled != disabled || !strings.Contains(e.LastError, "503 service unavailable") {
+			t.Errorf("%#v", e.FailureState)
+		}
+		expected := time.Now().Add(backoff).Unix()
+		if e.TimestampOfNextCheck < expected-5 || e.TimestampOfNextCheck > expected+5 {
+			t.Error("unexpected TimestampOfNextCheck", e.TimestampOfNextCheck, expected)
+		}
+	}
+
+	t.Run("first failure", func(t *testing.T) {
+		_, err := w.Run(10)
+		if !errors.Is(err, watcher.ErrCheckFailed) {
+			t.Error(err)
+			return
+		}
+		checkState(t, 1, false, 2*time.Hour)
+	})
+
+	t.Run("second failure is capped", func(t *testing.T) {
+		makeDue(t)
+		_, err := w.Run(10)
+		if !errors.Is(err, watcher.ErrCheckFailed) {
+			t.Error(err)
+			return
+		}
+		checkState(t, 2, false, 3*time.Hour)
+	})
+
+	t.Run("third failure disables", func(t *testing.T) {
+		makeDue(t)
+		_, err := w.Run(10)
+		if !errors.Is(err, watcher.ErrCheckFailed) {
+			t.Error(err)
+			return
+		}
+		checkState(t, 3, true, 3*time.Hour)
+	})
+
+	t.Run("disabled watcher is not checked", func(t *testing.T) {
+		makeDue(t)
+		count, err := w.Run(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if count != 0 || c.Get()["watch"] != 3 {
+			t.Error(count, c.Get())
+		}
+	})
+
+	t.Run("resume re-enables", func(t *testing.T) {
+		err := w.ResumeWatcher("user", "w1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err := database.Read("w1", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.Disabled || e.ConsecutiveFailures != 0 || e.TimestampOfNextCheck != 0 {
+			t.Errorf("%#v", e)
+		}
+	})
+
+	t.Run("success resets failures", func(t *testing.T) {
+		_, err := w.Run(10)
+		if !errors.Is(err, watcher.ErrCheckFailed) {
+			t.Error(err)
+			return
+		}
+		checkState(t, 1, false, 2*time.Hour)
+
+		delete(c.Errors, "watch")
+		makeDue(t)
+		_, err = w.Run(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err := database.Read("w1", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.FailureState != (model.FailureState{}) {
+			t.Errorf("%#v", e.FailureState)
+		}
+		expected := time.Now().Add(time.Hour).Unix()
+		if e.TimestampOfNextCheck < expected-5 || e.TimestampOfNextCheck > expected+5 {
+			t.Error("unexpected TimestampOfNextCheck", e.TimestampOfNextCheck, expected)
+		}
+	})
+}
+
+func TestStoredErrorsAreTruncated(t *testing.T) {
+	config := configuration.Config{
+		MaxFailureBackoff: "3h",
+		HistoryRetention:  10,
+	}
+	database, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = database.Set(model.WatchedEntityInit{
+		Id:       "w1",
+		UserId:   "user",
+		Interval: "1h",
+		Watch:    model.HttpRequest{Endpoint: "watch"},
+		Trigger:  model.HttpRequest{Endpoint: "trigger"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	c := mocks.NewCountingChecker()
+	c.Errors["watch"] = errors.New("503 service unavailable: " + strings.Repeat("ä", 100000))
+	w := watcher.New(config, database, c, mocks.NewCountingTrigger(), mocks.CleanupChecker{})
+
+	_, err = w.Run(10)
+	if !errors.Is(err, watcher.ErrCheckFailed) {
+		t.Error(err)
+		return
+	}
+
+	e, err := database.Read("w1", "user")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(e.LastError, "503 service unavailable") || !strings.HasSuffix(e.LastError, "...") || len(e.LastError) > 2048+3 || !utf8.ValidString(e.LastError) {
+		t.Error(len(e.LastError), e.LastError)
+	}
+
+	history, err := database.ListHistory("w1", "user", 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(history) != 1 || history[0].Error != e.LastError {
+		t.Errorf("%#v", history)
+	}
+}
@@ -0,0 +1,277 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/api"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/trigger"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/docker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestCheckNow(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	freePort, err := docker.GetFreePortString()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config := configuration.Config{
+		Database:      "memory",
+		AdvertisedUrl: "http://localhost:" + freePort,
+	}
+
+	db, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	c, err := checker.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tr, err := trigger.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	w := watcher.New(config, db, c, tr, mocks.CleanupChecker{})
+	err = api.Start(ctx, config, w)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(100 * time.Millisecond) //wait for the api to listen
+
+	targetUrl, mux, requests := mocks.StartTestHttpMock(ctx, wg, []mocks.HttpMockResponse{
+		{Code: 200, Payload: []byte("foo")},
+		{Code: 200, Payload: []byte("foo")},
+		{Code: 200, Payload: []byte("bar")},
+		{Code: 200, Payload: []byte("bar")},
+		{Code: 200},
+	})
+
+	err = db.Set(model.WatchedEntityInit{
+		Id:       "w1",
+		UserId:   "test-user",
+		Interval: "1h",
+		HashType: checker.HASH_TYPE_MD5,
+		Watch: model.HttpRequest{
+			Method:   "GET",
+			Endpoint: targetUrl + "/query",
+		},
+		Trigger: model.HttpRequest{
+			Method:   "POST",
+			Endpoint: targetUrl + "/set",
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	check := func(userId string, query string) (result model.ManualCheckResult, code int, err error) {
+		req, err := http.NewRequest(http.MethodPost, config.AdvertisedUrl+"/watcher/w1/check"+query, nil)
+		if err != nil {
+			return result, 0, err
+		}
+		token, err := mocks.AuthMock{}.GenerateUserTokenById(userId)
+		if err != nil {
+			return result, 0, err
+		}
+		req.Header.Set("Authorization", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return result, 0, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return result, resp.StatusCode, nil
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, resp.StatusCode, err
+	}
+
+	getStoredHash := func(t *testing.T) string {
+		entity, err := db.Read("w1", "test-user")
+		if err != nil {
+			t.Error(err)
+		}
+		return entity.LastHash
+	}
+
+	getRequestCount := func() int {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(*requests)
+	}
+
+	fooHash := ""
+
+	t.Run("dry run", func(t *testing.T) {
+		result, code, err := check("test-user", "?dry_run=true")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+			return
+		}
+		if !result.Changed || result.Hash == "" || result.StatusCode != 200 || !result.DryRun || result.Triggered {
+			t.Errorf("%#v", result)
+		}
+		if hash := getStoredHash(t); hash != "" {
+			t.Error(hash)
+		}
+		fooHash = result.Hash
+	})
+
+	t.Run("check stores hash", func(t *testing.T) {
+		result, code, err := check("test-user", "")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+			return
+		}
+		if !result.Changed || result.Hash != fooHash || result.DryRun || result.Triggered {
+			t.Errorf("%#v", result)
+		}
+		if hash := getStoredHash(t); hash != fooHash {
+			t.Error(hash)
+		}
+	})
+
+	t.Run("dry run detects change without trigger", func(t *testing.T) {
+		result, code, err := check("test-user", "?dry_run=true")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+			return
+		}
+		if !result.Changed || result.Hash == fooHash || result.Triggered {
+			t.Errorf("%#v", result)
+		}
+		if hash := getStoredHash(t); hash != fooHash {
+			t.Error(hash)
+		}
+		if count := getRequestCount(); count != 3 {
+			t.Error(count)
+		}
+	})
+
+	t.Run("check triggers", func(t *testing.T) {
+		result, code, err := check("test-user", "?dry_run=false")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+			return
+		}
+		if !result.Changed || result.Hash == fooHash || !result.Triggered {
+			t.Errorf("%#v", result)
+		}
+		if hash := getStoredHash(t); hash != result.Hash {
+			t.Error(hash)
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		if len(*requests) != 5 || (*requests)[4].Endpoint != "/set" {
+			t.Errorf("%#v", *requests)
+		}
+	})
+
+	t.Run("leased watcher gets 409", func(t *testing.T) {
+		_, err := db.ClaimLease("other-instance", "w1", "test-user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer db.ReleaseLease("other-instance", "w1", "test-user")
+		_, code, err := check("test-user", "")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusConflict {
+			t.Error(code)
+		}
+		if count := getRequestCount(); count != 5 {
+			t.Error(count)
+		}
+	})
+
+	t.Run("check releases lease", func(t *testing.T) {
+		entity, err := db.Read("w1", "test-user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if entity.LeaseOwner != "" || entity.LeaseExpiration != 0 {
+			t.Error(entity.LeaseOwner, entity.LeaseExpiration)
+		}
+	})
+
+	t.Run("invalid dry_run", func(t *testing.T) {
+		_, code, err := check("test-user", "?dry_run=foo")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusBadRequest {
+			t.Error(code)
+		}
+	})
+
+	t.Run("other user gets 404", func(t *testing.T) {
+		_, code, err := check("other-user", "?dry_run=true")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusNotFound {
+			t.Error(code)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+// conditionalServer answers with 304 if the request validators match the current content
+type conditionalServer struct {
+	mux          sync.Mutex
+	content      string
+	etag         string
+	lastModified string
+	requests     []http.Header
+}
+
+func (this *conditionalServer) set(content string, etag string, lastModified string) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.content = content
+	this.etag = etag
+	this.lastModified = lastModified
+}
+
+func (this *conditionalServer) lastRequest() http.Header {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.requests[len(this.requests)-1]
+}
+
+func (this *conditionalServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.requests = append(this.requests, request.Header.Clone())
+	if this.etag != "" {
+		writer.Header().Set("ETag", this.etag)
+	}
+	if this.lastModified != "" {
+		writer.Header().Set("Last-Modified", this.lastModified)
+	}
+	if (this.etag != "" && request.Header.Get("If-None-Match") == this.etag) ||
+		(this.etag == "" && this.lastModified != "" && request.Header.Get("If-Modified-Since") == this.lastModified) {
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writer.Write([]byte(this.content))
+}
+
+func TestConditionalRequests(t *testing.T) {
+	config := configuration.Config{}
+	m, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	database := mocks.NewFailingDb(m)
+	c, err := checker.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tr := mocks.NewCountingTrigger()
+	w := watcher.New(config, database, c, tr, mocks.CleanupChecker{})
+
+	server := &conditionalServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	err = database.Set(model.WatchedEntityInit{
+		Id:       "w1",
+		UserId:   "user",
+		Interval: "1h",
+		HashType: checker.HASH_TYPE_MD5,
+		Watch:    model.HttpRequest{Method: "GET", Endpoint: httpServer.URL},
+		Trigger:  model.HttpRequest{Endpoint: "trigger"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	check := func(t *testing.T, expectedStatus int, expectedChanged bool, expectedTriggers int) {
+		result, err := w.CheckNow("user", "w1", false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.StatusCode != expectedStatus || result.Changed != expectedChanged || tr.Get()["trigger"] != expectedTriggers {
+			t.Errorf("%#v %#v", result, tr.Get())
+		}
+	}
+
+	checkValidators := func(t *testing.T, etag string, lastModified string) {
+		e, err := database.Read("w1", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.ETag != etag || e.LastModified != lastModified {
+			t.Errorf("%#v", e.WatchedEntityFetchInfo)
+		}
+	}
+
+	t.Run("initial check is unconditional", func(t *testing.T) {
+		server.set("v1", `"v1"`, "")
+		check(t, http.StatusOK, true, 0)
+		if header := server.lastRequest(); header.Get("If-None-Match") != "" {
+			t.Error(header)
+		}
+		checkValidators(t, `"v1"`, "")
+	})
+
+	t.Run("304 is unchanged", func(t *testing.T) {
+		check(t, http.StatusNotModified, false, 0)
+		if header := server.lastRequest(); header.Get("If-None-Match") != `"v1"` {
+			t.Error(header)
+		}
+	})
+
+	t.Run("change", func(t *testing.T) {
+		server.set("v2", `"v2"`, "")
+		check(t, http.StatusOK, true, 1)
+		checkValidators(t, `"v2"`, "")
+		check(t, http.StatusNotModified, false, 1)
+	})
+
+	t.Run("validators are only promoted with the hash", func(t *testing.T) {
+		server.set("v3", `"v3"`, "")
+		injected := errors.New("injected failure")
+		database.SetError("PromotePendingHash", injected)
+		_, err := w.CheckNow("user", "w1", false)
+		database.SetError("PromotePendingHash", nil)
+		if !errors.Is(err, injected) {
+			t.Error(err)
+			return
+		}
+		checkValidators(t, `"v2"`, "")
+		//the old validators do not match, so the change is detected and triggered again
+		check(t, http.StatusOK, true, 3)
+		checkValidators(t, `"v3"`, "")
+	})
+
+	t.Run("last modified", func(t *testing.T) {
+		server.set("v3", "", "Wed, 21 Oct 2026 07:28:00 GMT")
+		//same content with new validators
+		check(t, http.StatusOK, false, 3)
+		checkValidators(t, "", "Wed, 21 Oct 2026 07:28:00 GMT")
+		check(t, http.StatusNotModified, false, 3)
+		if header := server.lastRequest(); header.Get("If-Modified-Since") != "Wed, 21 Oct 2026 07:28:00 GMT" {
+			t.Error(header)
+		}
+		server.set("v4", "", "Thu, 22 Oct 2026 07:28:00 GMT")
+		check(t, http.StatusOK, true, 4)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		result, err := w.CheckNow("user", "w1", true)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.StatusCode != http.StatusNotModified || result.Changed {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("response trigger input is requested unconditionally", func(t *testing.T) {
+		server.set(`{"state":"on"}`, `"v5"`, "")
+		err := database.Set(model.WatchedEntityInit{
+			Id:            "w2",
+			UserId:        "user",
+			Interval:      "1h",
+			HashType:      checker.HASH_TYPE_PREDICATE,
+			HashOptions:   model.HashOptions{Predicate: "true", PredicateMode: checker.PREDICATE_MODE_LEVEL},
+			Watch:         model.HttpRequest{Method: "GET", Endpoint: httpServer.URL},
+			Trigger:       model.HttpRequest{Endpoint: "w2"},
+			TriggerInputs: map[string]string{watcher.TRIGGER_INPUT_RESPONSE: "response"},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for i := range 3 {
+			result, err := w.CheckNow("user", "w2", false)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if result.StatusCode != http.StatusOK || tr.Get()["w2"] != i+1 {
+				t.Errorf("%#v %#v", result, tr.Get())
+			}
+			if header := server.lastRequest(); header.Get("If-None-Match") != "" {
+				t.Error(header)
+			}
+		}
+	})
+}
@@ -0,0 +1,952 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/api"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/mongo"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/docker"
+)
+
+// TestDbConformance runs the same expectations against every db.Database implementation
+func TestDbConformance(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		m, err := memory.New(configuration.Config{FetchLeaseDuration: "2s", HistoryRetention: 3})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		testDatabaseConformance(t, m)
+	})
+
+	t.Run("mongo", func(t *testing.T) {
+		wg := &sync.WaitGroup{}
+		defer wg.Wait()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mongoUrl, err := docker.MongoRs(ctx, wg)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		m, err := mongo.New(configuration.Config{
+			MongoUrl:                     mongoUrl,
+			MongoTable:                   "test",
+			MongoCollectionWatchedEntity: "conformance",
+			MongoUseRelSet:               true,
+			MongoCollectionHistory:       "conformance_history",
+			MongoCollectionTriggers:      "conformance_triggers",
+			FetchLeaseDuration:           "2s",
+			HistoryRetention:             3,
+		}, ctx)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		testDatabaseConformance(t, m)
+	})
+}
+
+// testDatabaseConformance expects database to be configured with a FetchLeaseDuration of 2s and a HistoryRetention of 3
+func testDatabaseConformance(t *testing.T, database db.Database) {
+	owner := "conformance"
+
+	t.Run("create entities", func(t *testing.T) {
+		for i := 0; i < 50; i++ {
+			err := database.Set(model.WatchedEntityInit{
+				Id:       strconv.Itoa(i),
+				UserId:   "user",
+				Interval: "1h",
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	})
+
+	done := map[string]bool{}
+
+	checkFetch := func(t *testing.T, max int64, expectedCount int) {
+		fetched, err := database.Fetch(owner, max)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != expectedCount {
+			t.Error(len(fetched), fetched)
+			return
+		}
+		for _, f := range fetched {
+			if done[f.Id] {
+				t.Error("duplicate fetch", f)
+				return
+			}
+			done[f.Id] = true
+			if f.TimestampOfNextCheck <= time.Now().Unix() {
+				t.Error("unexpected TimestampOfNextCheck", f.TimestampOfNextCheck)
+				return
+			}
+			stored, err := database.Read(f.Id, f.UserId)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if stored.TimestampOfNextCheck != f.TimestampOfNextCheck {
+				t.Error("fetched TimestampOfNextCheck is not stored", stored.TimestampOfNextCheck, f.TimestampOfNextCheck)
+				return
+			}
+			if stored.LeaseOwner != owner || stored.LeaseExpiration < time.Now().Unix() {
+				t.Error("fetched entity is not leased", stored.LeaseOwner, stored.LeaseExpiration)
+				return
+			}
+		}
+	}
+
+	t.Run("fetch 10 (1)", func(t *testing.T) {
+		checkFetch(t, 10, 10)
+	})
+
+	t.Run("fetch 10 (2)", func(t *testing.T) {
+		checkFetch(t, 10, 10)
+	})
+
+	t.Run("unfetched entities are still due", func(t *testing.T) {
+		for i := 0; i < 50; i++ {
+			id := strconv.Itoa(i)
+			e, err := database.Read(id, "user")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if done[id] != (e.TimestampOfNextCheck >= time.Now().Unix()) {
+				t.Error("unexpected TimestampOfNextCheck", id, done[id], e.TimestampOfNextCheck)
+			}
+			if e.CreatedAt == 0 {
+				t.Error("missing CreatedAt default", id)
+			}
+		}
+	})
+
+	t.Run("fetch remaining", func(t *testing.T) {
+		checkFetch(t, 100, 30)
+	})
+
+	t.Run("fetch nothing due", func(t *testing.T) {
+		checkFetch(t, 10, 0)
+	})
+
+	t.Run("invalid interval is interpreted as 1h", func(t *testing.T) {
+		err := database.Set(model.WatchedEntityInit{
+			Id:       "invalid",
+			UserId:   "user",
+			Interval: "foo",
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		fetched, err := database.Fetch(owner, 10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 1 || fetched[0].Id != "invalid" {
+			t.Error(fetched)
+			return
+		}
+		expected := time.Now().Add(time.Hour).Unix()
+		if fetched[0].TimestampOfNextCheck < expected-5 || fetched[0].TimestampOfNextCheck > expected+5 {
+			t.Error("unexpected TimestampOfNextCheck", fetched[0].TimestampOfNextCheck, expected)
+		}
+	})
+
+	t.Run("scheduled entities are checked at the next scheduled minute", func(t *testing.T) {
+		err := database.Set(model.WatchedEntityInit{
+			Id:       "scheduled",
+			UserId:   "schedule-user",
+			Interval: "1m",
+			Schedule: "0 * * * *",
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := time.Now().Truncate(time.Hour).Add(time.Hour).Unix()
+		entity, err := database.Read("scheduled", "schedule-user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if entity.TimestampOfNextCheck != expected {
+			t.Error("unexpected TimestampOfNextCheck after set", entity.TimestampOfNextCheck, expected)
+		}
+		err = database.UpdateFailureState("scheduled", "schedule-user", model.FailureState{}, time.Now().Unix()-1)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		fetched, err := database.Fetch(owner, 10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 1 || fetched[0].Id != "scheduled" {
+			t.Error(fetched)
+			return
+		}
+		if fetched[0].TimestampOfNextCheck != expected {
+			t.Error("unexpected TimestampOfNextCheck after fetch", fetched[0].TimestampOfNextCheck, expected)
+		}
+	})
+
+	t.Run("release lease", func(t *testing.T) {
+		err := database.ReleaseLease("other-owner", "0", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err := database.Read("0", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.LeaseOwner != owner {
+			t.Error("lease released by foreign owner", e.LeaseOwner)
+			return
+		}
+		err = database.ReleaseLease(owner, "0", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err = database.Read("0", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.LeaseOwner != "" || e.LeaseExpiration != 0 {
+			t.Error("lease not released", e.LeaseOwner, e.LeaseExpiration)
+			return
+		}
+	})
+
+	t.Run("claim lease", func(t *testing.T) {
+		_, err := database.ClaimLease(owner, "unknown", "user")
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Error(err)
+			return
+		}
+		e, err := database.ClaimLease(owner, "0", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.Id != "0" || e.LeaseOwner != owner || e.LeaseExpiration < time.Now().Unix() {
+			t.Error(e.Id, e.LeaseOwner, e.LeaseExpiration)
+			return
+		}
+		_, err = database.ClaimLease("other-owner", "0", "user")
+		if !errors.Is(err, db.ErrLeased) {
+			t.Error(err)
+			return
+		}
+		_, err = database.ClaimLease(owner, "0", "user")
+		if !errors.Is(err, db.ErrLeased) {
+			t.Error(err)
+			return
+		}
+		err = database.ReleaseLease(owner, "0", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = database.ClaimLease("other-owner", "0", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = database.ReleaseLease("other-owner", "0", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("leased entities are skipped until the lease expires", func(t *testing.T) {
+		err := database.Set(model.WatchedEntityInit{
+			Id:       "lease",
+			UserId:   "user",
+			Interval: "1ms",
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		fetched, err := database.Fetch("crashing-owner", 10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 1 || fetched[0].Id != "lease" {
+			t.Error(fetched)
+			return
+		}
+
+		//due again but still leased
+		time.Sleep(1100 * time.Millisecond)
+		fetched, err = database.Fetch(owner, 10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 0 {
+			t.Error(fetched)
+			return
+		}
+
+		//lease expired
+		time.Sleep(2100 * time.Millisecond)
+		fetched, err = database.Fetch(owner, 10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 1 || fetched[0].Id != "lease" || fetched[0].LeaseOwner != owner {
+			t.Error(fetched)
+			return
+		}
+		err = database.Delete("lease", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("update hash", func(t *testing.T) {
+		err := database.UpdateHash("2", "user", "foobar")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err := database.Read("2", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.LastHash != "foobar" || e.Id != "2" {
+			t.Error(e)
+		}
+		e, err = database.Read("3", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.LastHash != "" || e.Id != "3" {
+			t.Error(e)
+		}
+	})
+
+	t.Run("pending hash", func(t *testing.T) {
+		err := database.SetPendingHash("5", "user", "pending")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = database.PromotePendingHash("5", "user", model.CheckResult{Hash: "other", DeviceIds: []string{"d1"}, ETag: "e1"}, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err := database.Read("5", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.PendingHash != "pending" || e.LastHash == "other" || len(e.LastDeviceIds) != 0 || e.ETag != "" {
+			t.Error("promoted hash that is not pending", e)
+			return
+		}
+		err = database.PromotePendingHash("5", "user", model.CheckResult{Hash: "pending", DeviceIds: []string{"d1", "d2"}, ETag: "e2", LastModified: "m2"}, &model.PendingTrigger{Id: "outbox", WatcherId: "5", UserId: "user"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err = database.Read("5", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.PendingHash != "" || e.LastHash != "pending" || !reflect.DeepEqual(e.LastDeviceIds, []string{"d1", "d2"}) || e.ETag != "e2" || e.LastModified != "m2" {
+			t.Error(e)
+			return
+		}
+		_, err = database.ReadPendingTrigger("outbox", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = database.RemovePendingTrigger("outbox")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = database.SetPendingHash("5", "other-user", "pending")
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("update validators", func(t *testing.T) {
+		err := database.UpdateValidators("5", "user", "e3", "m3")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err := database.Read("5", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.ETag != "e3" || e.LastModified != "m3" || e.LastHash != "pending" {
+			t.Error(e)
+			return
+		}
+		err = database.UpdateValidators("5", "other-user", "e3", "m3")
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("set replaces entity and resets fetch info", func(t *testing.T) {
+		err := database.Set(model.WatchedEntityInit{
+			Id:       "2",
+			UserId:   "user",
+			Interval: "2h",
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err := database.Read("2", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.LastHash != "" || e.TimestampOfNextCheck != 0 || e.Interval != "2h" {
+			t.Error(e)
+		}
+	})
+
+	t.Run("entities are scoped by user", func(t *testing.T) {
+		_, err := database.Read("3", "other-user")
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Error(err)
+			return
+		}
+		err = database.Delete("3", "other-user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = database.Read("3", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("list by source type", func(t *testing.T) {
+		for _, e := range []model.WatchedEntityInit{
+			{Id: "source-1", UserId: "user", Interval: "1h", SourceType: "push"},
+			{Id: "source-2", UserId: "other-user", Interval: "1h", SourceType: "push"},
+			{Id: "source-3", UserId: "user", Interval: "1h", SourceType: "other"},
+		} {
+			err := database.Set(e)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		list, err := database.ListBySourceType("push")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		ids := []string{}
+		for _, e := range list {
+			ids = append(ids, e.UserId+"/"+e.Id)
+		}
+		slices.Sort(ids)
+		if !reflect.DeepEqual(ids, []string{"other-user/source-2", "user/source-1"}) {
+			t.Error(ids)
+		}
+		for _, e := range []model.WatchedEntityInit{{Id: "source-1", UserId: "user"}, {Id: "source-2", UserId: "other-user"}, {Id: "source-3", UserId: "user"}} {
+			err = database.Delete(e.Id, e.UserId)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	})
+
+	t.Run("paused entities are not fetched", func(t *testing.T) {
+		err := database.Set(model.WatchedEntityInit{
+			Id:       "paused",
+			UserId:   "user",
+			Interval: "1h",
+			Paused:   true,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = database.UpdateHash("paused", "user", "paused-hash")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		fetched, err := database.Fetch(owner, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for _, f := range fetched {
+			if f.Id == "paused" {
+				t.Error(fetched)
+				return
+			}
+		}
+	})
+
+	t.Run("resumed entities are fetched with their last hash", func(t *testing.T) {
+		err := database.SetPaused("paused", "user", false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		fetched, err := database.Fetch(owner, 10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 1 || fetched[0].Id != "paused" || fetched[0].Paused || fetched[0].LastHash != "paused-hash" {
+			t.Error(fetched)
+			return
+		}
+	})
+
+	t.Run("pause keeps last hash", func(t *testing.T) {
+		err := database.SetPaused("paused", "user", true)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err := database.Read("paused", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !e.Paused || e.LastHash != "paused-hash" {
+			t.Error(e)
+		}
+		err = database.Delete("paused", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("disabled entities are not fetched", func(t *testing.T) {
+		err := database.Set(model.WatchedEntityInit{
+			Id:       "failing",
+			UserId:   "user",
+			Interval: "1h",
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		nextCheck := time.Now().Add(-time.Minute).Unix()
+		err = database.UpdateFailureState("failing", "user", model.FailureState{ConsecutiveFailures: 3, LastError: "foo", Disabled: true}, nextCheck)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		e, err := database.Read("failing", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.ConsecutiveFailures != 3 || e.LastError != "foo" || !e.Disabled || e.TimestampOfNextCheck != nextCheck {
+			t.Error(e)
+			return
+		}
+		fetched, err := database.Fetch(owner, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for _, f := range fetched {
+			if f.Id == "failing" {
+				t.Error(fetched)
+				return
+			}
+		}
+	})
+
+	t.Run("re-enabled entities are fetched", func(t *testing.T) {
+		err := database.UpdateFailureState("failing", "user", model.FailureState{}, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		fetched, err := database.Fetch(owner, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 1 || fetched[0].Id != "failing" || fetched[0].Disabled || fetched[0].ConsecutiveFailures != 0 || fetched[0].LastError != "" {
+			t.Error(fetched)
+			return
+		}
+		err = database.Delete("failing", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("update failure state of unknown entity", func(t *testing.T) {
+		err := database.UpdateFailureState("3", "other-user", model.FailureState{}, 0)
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("pause unknown entity", func(t *testing.T) {
+		err := database.SetPaused("3", "other-user", true)
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Error(err)
+			return
+		}
+	})
+
+	listIds := func(t *testing.T, userId string, query api.ListQuery) (ids []string) {
+		list, err := database.ListByUser(userId, query)
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+		ids = []string{}
+		for _, e := range list {
+			if e.UserId != userId {
+				t.Error("unexpected user", e)
+			}
+			ids = append(ids, e.Id)
+		}
+		return ids
+	}
+
+	t.Run("list by user", func(t *testing.T) {
+		ids := listIds(t, "user", api.ListQuery{Limit: 5, Sort: "id.asc"})
+		if !reflect.DeepEqual(ids, []string{"0", "1", "10", "11", "12"}) {
+			t.Error(ids)
+		}
+	})
+
+	t.Run("list by user with offset", func(t *testing.T) {
+		ids := listIds(t, "user", api.ListQuery{Limit: 3, Offset: 2, Sort: "id.asc"})
+		if !reflect.DeepEqual(ids, []string{"10", "11", "12"}) {
+			t.Error(ids)
+		}
+	})
+
+	t.Run("list by user descending", func(t *testing.T) {
+		ids := listIds(t, "user", api.ListQuery{Limit: 2, Sort: "id.desc"})
+		if !reflect.DeepEqual(ids, []string{"invalid", "9"}) {
+			t.Error(ids)
+		}
+	})
+
+	t.Run("list by user sorted by interval", func(t *testing.T) {
+		ids := listIds(t, "user", api.ListQuery{Limit: 2, Sort: "interval.desc"})
+		if !reflect.DeepEqual(ids, []string{"invalid", "2"}) {
+			t.Error(ids)
+		}
+	})
+
+	t.Run("list by user without limit", func(t *testing.T) {
+		ids := listIds(t, "user", api.ListQuery{Sort: "id.asc"})
+		if len(ids) != 51 {
+			t.Error(len(ids))
+		}
+	})
+
+	t.Run("list of other user is empty", func(t *testing.T) {
+		ids := listIds(t, "other-user", api.ListQuery{Limit: 10, Sort: "id.asc"})
+		if ids == nil || len(ids) != 0 {
+			t.Error(ids)
+		}
+	})
+
+	start := time.Now().Truncate(time.Millisecond)
+
+	t.Run("add history", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			err := database.AddHistoryEntry(model.HistoryEntry{
+				WatcherId:  "4",
+				UserId:     "user",
+				Timestamp:  start.Add(time.Duration(i) * time.Second),
+				StatusCode: 200,
+				NewHash:    strconv.Itoa(i),
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	})
+
+	t.Run("list history", func(t *testing.T) {
+		list, err := database.ListHistory("4", "user", 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 3 {
+			t.Error("retention not applied", len(list), list)
+			return
+		}
+		for i, entry := range list {
+			expectedHash := strconv.Itoa(4 - i)
+			if entry.NewHash != expectedHash || entry.StatusCode != 200 || !entry.Timestamp.Equal(start.Add(time.Duration(4-i)*time.Second)) {
+				t.Error(i, entry)
+			}
+		}
+	})
+
+	t.Run("list history with limit", func(t *testing.T) {
+		list, err := database.ListHistory("4", "user", 2)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 2 || list[0].NewHash != "4" || list[1].NewHash != "3" {
+			t.Error(list)
+			return
+		}
+	})
+
+	t.Run("history is scoped by user", func(t *testing.T) {
+		list, err := database.ListHistory("4", "other-user", 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 0 {
+			t.Error(list)
+			return
+		}
+	})
+
+	t.Run("add pending triggers", func(t *testing.T) {
+		for i, id := range []string{"t1", "t2", "t3"} {
+			err := database.AddPendingTrigger(model.PendingTrigger{
+				Id:          id,
+				WatcherId:   "3",
+				UserId:      "user",
+				Trigger:     model.HttpRequest{Endpoint: "trigger"},
+				CreatedAt:   start.Add(time.Duration(i) * time.Second),
+				Attempts:    1,
+				NextAttempt: time.Now().Unix() - int64(10-i),
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		err := database.AddPendingTrigger(model.PendingTrigger{
+			Id:          "t4",
+			WatcherId:   "3",
+			UserId:      "user",
+			Attempts:    3,
+			NextAttempt: time.Now().Add(time.Hour).Unix(),
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("fetch pending triggers", func(t *testing.T) {
+		fetched, err := database.FetchPendingTriggers(2)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 2 || fetched[0].Id != "t1" || fetched[1].Id != "t2" || fetched[0].Trigger.Endpoint != "trigger" || fetched[0].Attempts != 1 {
+			t.Error(fetched)
+			return
+		}
+		if fetched[0].NextAttempt <= time.Now().Unix() {
+			t.Error("fetched trigger is not leased", fetched[0].NextAttempt)
+		}
+		fetched, err = database.FetchPendingTriggers(0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 1 || fetched[0].Id != "t3" {
+			t.Error(fetched)
+			return
+		}
+	})
+
+	t.Run("dead letters", func(t *testing.T) {
+		for _, id := range []string{"t2", "t1"} {
+			trigger, err := database.ReadPendingTrigger(id, "user")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			trigger.DeadLetter = true
+			trigger.NextAttempt = 0
+			trigger.LastError = "foo"
+			err = database.UpdatePendingTrigger(trigger)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		list, err := database.ListDeadLetters("user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 2 || list[0].Id != "t1" || list[1].Id != "t2" || !list[0].DeadLetter || list[0].LastError != "foo" || !list[0].CreatedAt.Equal(start) {
+			t.Error(list)
+			return
+		}
+		fetched, err := database.FetchPendingTriggers(0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 0 {
+			t.Error(fetched)
+			return
+		}
+	})
+
+	t.Run("pending triggers are scoped by user", func(t *testing.T) {
+		_, err := database.ReadPendingTrigger("t1", "other-user")
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Error(err)
+			return
+		}
+		list, err := database.ListDeadLetters("other-user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if list == nil || len(list) != 0 {
+			t.Error(list)
+			return
+		}
+	})
+
+	t.Run("remove pending trigger", func(t *testing.T) {
+		err := database.RemovePendingTrigger("t2")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = database.ReadPendingTrigger("t2", "user")
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Error(err)
+			return
+		}
+		err = database.UpdatePendingTrigger(model.PendingTrigger{Id: "t2", UserId: "user"})
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := database.Delete("4", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = database.Read("4", "user")
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Error(err)
+			return
+		}
+		list, err := database.ListHistory("4", "user", 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 0 {
+			t.Error("history not deleted", list)
+			return
+		}
+	})
+
+	t.Run("delete removes pending triggers", func(t *testing.T) {
+		err := database.Delete("3", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for _, id := range []string{"t1", "t3", "t4"} {
+			_, err = database.ReadPendingTrigger(id, "user")
+			if !errors.Is(err, db.ErrNotFound) {
+				t.Error(id, err)
+			}
+		}
+	})
+
+	t.Run("delete idempotent", func(t *testing.T) {
+		err := database.Delete("4", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+}
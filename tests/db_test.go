@@ -71,7 +71,7 @@ func TestDb(t *testing.T) {
 	done := map[string]bool{}
 
 	t.Run("fetch 10 (1)", func(t *testing.T) {
-		fetched, err := m.Fetch(10)
+		fetched, err := m.Fetch("test", 10)
 		if err != nil {
 			t.Error(err)
 			return
@@ -135,7 +135,7 @@ func TestDb(t *testing.T) {
 	})
 
 	t.Run("fetch 10 (2)", func(t *testing.T) {
-		fetched, err := m.Fetch(10)
+		fetched, err := m.Fetch("test", 10)
 		if err != nil {
 			t.Error(err)
 			return
@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/trigger"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestDeviceDiff(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config := configuration.Config{}
+	db, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	c, err := checker.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tr, err := trigger.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	w := watcher.New(config, db, c, tr, mocks.CleanupChecker{})
+
+	d1 := "urn:infai:ses:device:11111111-1111-1111-1111-111111111111"
+	d2 := "urn:infai:ses:device:22222222-2222-2222-2222-222222222222"
+	devices := func(ids ...string) mocks.HttpMockResponse {
+		payload, _ := json.Marshal(ids)
+		return mocks.HttpMockResponse{Code: 200, Payload: payload}
+	}
+
+	//creates a watcher with its own http mock; responses are used in request order by watch and trigger requests
+	create := func(t *testing.T, id string, condition string, responses []mocks.HttpMockResponse) (mux *sync.Mutex, requests *[]model.HttpRequest) {
+		mux, requests, err := mocks.SetHttpMockEntity(ctx, wg, db, model.WatchedEntityInit{
+			Id:       id,
+			HashType: checker.HASH_TYPE_DEVICEIDS,
+			Watch:    model.HttpRequest{Endpoint: "/devices"},
+			Trigger: model.HttpRequest{
+				Endpoint: "/start",
+				Body:     []byte(`[{"id":"foo","label":"foo","value":"bar","value_label":"bar"},{"id":"removed","label":"removed","value":"x","value_label":"x"}]`),
+			},
+			TriggerCondition: condition,
+			TriggerInputs: map[string]string{
+				watcher.TRIGGER_INPUT_ADDED_DEVICES:   "added",
+				watcher.TRIGGER_INPUT_REMOVED_DEVICES: "removed",
+			},
+		}, responses)
+		if err != nil {
+			t.Error(err)
+		}
+		return mux, requests
+	}
+
+	check := func(t *testing.T, id string, expectedTriggered bool, expectedDiff model.DeviceDiff) {
+		result, err := w.CheckNow("user", id, false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !result.Changed || result.Triggered != expectedTriggered || result.DeviceDiff == nil || !reflect.DeepEqual(*result.DeviceDiff, expectedDiff) {
+			t.Errorf("%#v %#v", result, result.DeviceDiff)
+		}
+	}
+
+	t.Run("added_only", func(t *testing.T) {
+		mux, requests := create(t, "added", watcher.TRIGGER_CONDITION_ADDED_ONLY, []mocks.HttpMockResponse{
+			devices(d1),
+			devices(d1, d2),
+			{Code: 200},
+			devices(d2),
+			devices(d2, d1, d1),
+			{Code: 200},
+		})
+		check(t, "added", false, model.DeviceDiff{Added: []string{d1}, Removed: []string{}})
+		check(t, "added", true, model.DeviceDiff{Added: []string{d2}, Removed: []string{}})
+		check(t, "added", false, model.DeviceDiff{Added: []string{}, Removed: []string{d1}})
+		check(t, "added", true, model.DeviceDiff{Added: []string{d1}, Removed: []string{}})
+
+		entity, err := db.Read("added", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(entity.LastDeviceIds, []string{d1, d1, d2}) {
+			t.Error(entity.LastDeviceIds)
+		}
+
+		mux.Lock()
+		defer mux.Unlock()
+		if len(*requests) != 6 || (*requests)[2].Endpoint != "/start" || (*requests)[5].Endpoint != "/start" {
+			t.Errorf("%#v", *requests)
+			return
+		}
+		expected := map[string]interface{}{"foo": "bar", "added": []interface{}{d2}, "removed": []interface{}{}}
+		if inputs, err := mocks.GetTriggerInputs((*requests)[2]); err != nil || !reflect.DeepEqual(inputs, expected) {
+			t.Error(err, inputs)
+		}
+		expected = map[string]interface{}{"foo": "bar", "added": []interface{}{d1}, "removed": []interface{}{}}
+		if inputs, err := mocks.GetTriggerInputs((*requests)[5]); err != nil || !reflect.DeepEqual(inputs, expected) {
+			t.Error(err, inputs)
+		}
+	})
+
+	t.Run("removed_only", func(t *testing.T) {
+		mux, requests := create(t, "removed", watcher.TRIGGER_CONDITION_REMOVED_ONLY, []mocks.HttpMockResponse{
+			devices(d1),
+			devices(d1, d2),
+			devices(d2),
+			{Code: 200},
+		})
+		check(t, "removed", false, model.DeviceDiff{Added: []string{d1}, Removed: []string{}})
+		check(t, "removed", false, model.DeviceDiff{Added: []string{d2}, Removed: []string{}})
+		check(t, "removed", true, model.DeviceDiff{Added: []string{}, Removed: []string{d1}})
+
+		mux.Lock()
+		defer mux.Unlock()
+		if len(*requests) != 4 || (*requests)[3].Endpoint != "/start" {
+			t.Errorf("%#v", *requests)
+			return
+		}
+		expected := map[string]interface{}{"foo": "bar", "added": []interface{}{}, "removed": []interface{}{d1}}
+		if inputs, err := mocks.GetTriggerInputs((*requests)[3]); err != nil || !reflect.DeepEqual(inputs, expected) {
+			t.Error(err, inputs)
+		}
+	})
+
+	t.Run("any change", func(t *testing.T) {
+		mux, requests := create(t, "any", "", []mocks.HttpMockResponse{
+			devices(d1),
+			devices(d2),
+			{Code: 200},
+		})
+		check(t, "any", false, model.DeviceDiff{Added: []string{d1}, Removed: []string{}})
+		check(t, "any", true, model.DeviceDiff{Added: []string{d2}, Removed: []string{d1}})
+
+		mux.Lock()
+		defer mux.Unlock()
+		if len(*requests) != 3 || (*requests)[2].Endpoint != "/start" {
+			t.Errorf("%#v", *requests)
+		}
+	})
+}
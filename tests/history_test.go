@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/api"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/trigger"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/docker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestHistory(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	freePort, err := docker.GetFreePortString()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config := configuration.Config{
+		Database:           "memory",
+		ExternalDnsAddress: "8.8.8.8:53",
+		AdvertisedUrl:      "http://localhost:" + freePort,
+		HistoryRetention:   10,
+	}
+
+	db, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	c, err := checker.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tr, err := trigger.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	w := watcher.New(config, db, c, tr, mocks.CleanupChecker{})
+	err = api.Start(ctx, config, w)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	targetUrl, _, _ := mocks.StartTestHttpMock(ctx, wg, []mocks.HttpMockResponse{
+		{Code: 200, Payload: []byte("foo")},
+		{Code: 200, Payload: []byte("bar")},
+		{Code: 200},
+		{Code: 500, Payload: []byte("error")},
+	})
+
+	err = db.Set(model.WatchedEntityInit{
+		Id:       "w1",
+		UserId:   "test-user",
+		Interval: "1ms",
+		HashType: checker.HASH_TYPE_MD5,
+		Watch: model.HttpRequest{
+			Method:   "GET",
+			Endpoint: targetUrl + "/query",
+		},
+		Trigger: model.HttpRequest{
+			Method:   "POST",
+			Endpoint: targetUrl + "/set",
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("run checks", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if i > 0 {
+				time.Sleep(1100 * time.Millisecond)
+			}
+			count, _ := w.Run(10)
+			if count != 1 {
+				t.Error(i, count)
+				return
+			}
+		}
+	})
+
+	getHistory := func(userId string) (result []model.HistoryEntry, code int, err error) {
+		req, err := http.NewRequest(http.MethodGet, config.AdvertisedUrl+"/watcher/w1/history", nil)
+		if err != nil {
+			return result, 0, err
+		}
+		token, err := mocks.AuthMock{}.GenerateUserTokenById(userId)
+		if err != nil {
+			return result, 0, err
+		}
+		req.Header.Set("Authorization", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return result, 0, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return result, resp.StatusCode, nil
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, resp.StatusCode, err
+	}
+
+	t.Run("owner reads history", func(t *testing.T) {
+		history, code, err := getHistory("test-user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+			return
+		}
+		if len(history) != 3 {
+			t.Error(len(history), history)
+			return
+		}
+		failed, triggered, initial := history[0], history[1], history[2]
+		if initial.StatusCode != 200 || initial.OldHash != "" || initial.NewHash == "" || initial.Triggered || initial.Error != "" {
+			t.Errorf("%#v", initial)
+		}
+		if triggered.StatusCode != 200 || triggered.OldHash != initial.NewHash || triggered.NewHash == initial.NewHash || !triggered.Triggered || triggered.Error != "" {
+			t.Errorf("%#v", triggered)
+		}
+		if failed.StatusCode != 500 || failed.OldHash != triggered.NewHash || failed.NewHash != "" || failed.Triggered || failed.Error == "" {
+			t.Errorf("%#v", failed)
+		}
+		for _, entry := range history {
+			if entry.WatcherId != "w1" || entry.UserId != "test-user" || entry.Timestamp.IsZero() {
+				t.Errorf("%#v", entry)
+			}
+		}
+	})
+
+	t.Run("other user gets 404", func(t *testing.T) {
+		_, code, err := getHistory("other-user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusNotFound {
+			t.Error(code)
+			return
+		}
+	})
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+)
+
+func TestJitter(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	config := configuration.Config{CheckInitialJitter: "10m", CheckJitter: "1m", CheckJitterSeed: 42}
+
+	//entities of one bulk deployment share interval and creation time
+	entities := []model.WatchedEntityInit{}
+	for i := range 100 {
+		entities = append(entities, model.WatchedEntityInit{Id: strconv.Itoa(i), UserId: "user", Interval: "1h", CreatedAt: now.Unix()})
+	}
+
+	timestamps := func(scheduler *db.Scheduler, first bool) (result []time.Time) {
+		for _, entity := range entities {
+			var next time.Time
+			var err error
+			if first {
+				next, err = scheduler.FirstCheck(entity, now)
+			} else {
+				next, err = scheduler.NextCheck(entity, now)
+			}
+			if err != nil {
+				t.Error(err)
+			}
+			result = append(result, next)
+		}
+		return result
+	}
+
+	checkSpread := func(t *testing.T, list []time.Time, min time.Time, max time.Time) {
+		distinct := map[int64]bool{}
+		for _, ts := range list {
+			if ts.Before(min) || !ts.Before(max) {
+				t.Error("out of range", ts, min, max)
+			}
+			distinct[ts.Unix()] = true
+		}
+		if len(distinct) < 20 {
+			t.Error("expected spread timestamps", len(distinct))
+		}
+	}
+
+	t.Run("initial offset", func(t *testing.T) {
+		checkSpread(t, timestamps(db.NewScheduler(config), true), now, now.Add(10*time.Minute))
+	})
+
+	t.Run("per cycle spread", func(t *testing.T) {
+		checkSpread(t, timestamps(db.NewScheduler(config), false), now.Add(time.Hour), now.Add(time.Hour+time.Minute))
+	})
+
+	t.Run("deterministic for seed and clock", func(t *testing.T) {
+		a := timestamps(db.NewScheduler(config), false)
+		b := timestamps(db.NewScheduler(config), false)
+		other := config
+		other.CheckJitterSeed = 7
+		c := timestamps(db.NewScheduler(other), false)
+		differs := false
+		for i := range a {
+			if !a[i].Equal(b[i]) {
+				t.Error(i, a[i], b[i])
+			}
+			if !a[i].Equal(c[i]) {
+				differs = true
+			}
+		}
+		if !differs {
+			t.Error("expected different jitter for different seed")
+		}
+	})
+
+	t.Run("schedule is delayed, never advanced", func(t *testing.T) {
+		scheduler := db.NewScheduler(config)
+		entity := model.WatchedEntityInit{Id: "scheduled", UserId: "user", Schedule: "0 6 * * *"}
+		scheduled := time.Date(2026, 10, 17, 6, 0, 0, 0, time.UTC)
+		next, err := scheduler.NextCheck(entity, now)
+		if err != nil {
+			t.Error(err)
+		}
+		if next.Before(scheduled) || !next.Before(scheduled.Add(time.Minute)) {
+			t.Error(next)
+		}
+		first, err := scheduler.FirstCheck(entity, now)
+		if err != nil {
+			t.Error(err)
+		}
+		if first.Before(scheduled) || !first.Before(scheduled.Add(10*time.Minute)) {
+			t.Error(first)
+		}
+	})
+
+	t.Run("without jitter", func(t *testing.T) {
+		scheduler := db.NewScheduler(configuration.Config{})
+		for _, first := range timestamps(scheduler, true) {
+			if first.Unix() != 0 {
+				t.Error("expected immediate first check", first)
+			}
+		}
+		for _, next := range timestamps(scheduler, false) {
+			if !next.Equal(now.Add(time.Hour)) {
+				t.Error(next)
+			}
+		}
+	})
+
+	t.Run("memory set applies initial offset", func(t *testing.T) {
+		database, err := memory.New(config)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		start := time.Now()
+		for _, entity := range entities {
+			err = database.Set(entity)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		fetched, err := database.Fetch("owner", 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(fetched) != 0 {
+			t.Error("expected delayed first checks", len(fetched))
+		}
+		list := []time.Time{}
+		for _, entity := range entities {
+			stored, err := database.Read(entity.Id, entity.UserId)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			list = append(list, time.Unix(stored.TimestampOfNextCheck, 0))
+		}
+		checkSpread(t, list, start.Truncate(time.Second), start.Add(10*time.Minute+time.Second))
+	})
+}
@@ -0,0 +1,281 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source/kafka"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestKafkaSource(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config := configuration.Config{}
+	database, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	broker := mocks.NewKafkaMock()
+	registry, err := pkg.NewSources(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = registry.Register(kafka.SOURCE_TYPE, kafka.New(config, broker.NewReader))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tr := mocks.NewCountingTrigger()
+	w := watcher.New(config, database, registry, tr, mocks.CleanupChecker{})
+
+	//existing watchers are subscribed on start, without a previous check
+	err = database.Set(model.WatchedEntityInit{
+		Id:           "restored",
+		UserId:       "user",
+		Interval:     "1h",
+		SourceType:   kafka.SOURCE_TYPE,
+		SourceConfig: json.RawMessage(`{"topic":"restored","debounce":"50ms"}`),
+		Trigger:      model.HttpRequest{Endpoint: "restored"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = database.UpdateHash("restored", "user", kafka.HASH_NO_MESSAGE)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = registry.Start(ctx, wg, w)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	set := func(t *testing.T, id string, sourceConfig kafka.Config) {
+		raw, err := json.Marshal(sourceConfig)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = w.Set(model.WatchedEntityInit{
+			Id:           id,
+			UserId:       "user",
+			Interval:     "1h",
+			SourceType:   kafka.SOURCE_TYPE,
+			SourceConfig: raw,
+			Trigger:      model.HttpRequest{Endpoint: id},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		//the first check subscribes the entity
+		result, err := w.CheckNow("user", id, false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Hash != kafka.HASH_NO_MESSAGE || tr.Get()[id] != 0 {
+			t.Errorf("%#v %#v", result, tr.Get())
+		}
+	}
+
+	checkTriggers := func(t *testing.T, expected map[string]int) {
+		time.Sleep(300 * time.Millisecond)
+		actual := tr.Get()
+		for id, count := range expected {
+			if actual[id] != count {
+				t.Errorf("%v: %v != %v", id, actual[id], count)
+			}
+		}
+	}
+
+	checkHash := func(t *testing.T, id string, expected string) {
+		entity, err := database.Read(id, "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if entity.LastHash != expected {
+			t.Errorf("%v: %v != %v", id, entity.LastHash, expected)
+		}
+	}
+
+	t.Run("invalid config is rejected", func(t *testing.T) {
+		err := w.Set(model.WatchedEntityInit{Id: "invalid", UserId: "user", Interval: "1h", SourceType: kafka.SOURCE_TYPE, SourceConfig: json.RawMessage(`{"topic":""}`)})
+		if err == nil {
+			t.Error("expected error")
+		}
+		err = w.Set(model.WatchedEntityInit{Id: "invalid", UserId: "user", Interval: "1h", SourceType: kafka.SOURCE_TYPE, SourceConfig: json.RawMessage(`{"topic":"devices","filter":"foo =="}`)})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("hash options are rejected", func(t *testing.T) {
+		raw := json.RawMessage(`{"topic":"devices"}`)
+		for _, entity := range []model.WatchedEntityInit{
+			{HashType: "predicate", HashOptions: model.HashOptions{Predicate: "state == \"on\""}},
+			{HashType: "deviceids"},
+			{HashOptions: model.HashOptions{HashIgnoreFields: []string{"time"}}},
+			{HashOptions: model.HashOptions{Predicate: "state == \"on\""}},
+			{TriggerCondition: "added_only"},
+		} {
+			entity.Id = "invalid"
+			entity.UserId = "user"
+			entity.Interval = "1h"
+			entity.SourceType = kafka.SOURCE_TYPE
+			entity.SourceConfig = raw
+			err := w.Set(entity)
+			if err == nil {
+				t.Errorf("expected error %#v", entity)
+			}
+		}
+		err := w.Set(model.WatchedEntityInit{Id: "md5", UserId: "user", Interval: "1h", HashType: "md5", SourceType: kafka.SOURCE_TYPE, SourceConfig: raw})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = w.DeleteWatcher("user", "md5")
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	set(t, "by-key", kafka.Config{Topic: "devices", Key: "d1", Debounce: "50ms"})
+	set(t, "by-field", kafka.Config{Topic: "devices", Filter: `device_id == "d2" && state == "on"`, Debounce: "50ms"})
+	set(t, "all", kafka.Config{Topic: "devices", Debounce: "50ms"})
+	if broker.Readers("devices") != 1 {
+		t.Error("expected one reader per topic", broker.Readers("devices"))
+	}
+
+	t.Run("subscribed on start", func(t *testing.T) {
+		if broker.Readers("restored") != 1 {
+			t.Error(broker.Readers("restored"))
+		}
+		offset := broker.Publish("restored", "x", `{}`)
+		checkTriggers(t, map[string]int{"restored": 1})
+		checkHash(t, "restored", "0:"+strconv.FormatInt(offset, 10))
+	})
+
+	t.Run("filter by key", func(t *testing.T) {
+		offset := broker.Publish("devices", "d1", `{"device_id":"d1"}`)
+		checkTriggers(t, map[string]int{"by-key": 1, "by-field": 0, "all": 1})
+		checkHash(t, "by-key", "0:"+strconv.FormatInt(offset, 10))
+	})
+
+	t.Run("filter by field", func(t *testing.T) {
+		broker.Publish("devices", "x", `{"device_id":"d2","state":"off"}`)
+		broker.Publish("devices", "x", `not json`)
+		checkTriggers(t, map[string]int{"by-key": 1, "by-field": 0, "all": 2})
+		offset := broker.Publish("devices", "x", `{"device_id":"d2","state":"on"}`)
+		checkTriggers(t, map[string]int{"by-key": 1, "by-field": 1, "all": 3})
+		checkHash(t, "by-field", "0:"+strconv.FormatInt(offset, 10))
+	})
+
+	t.Run("debounce", func(t *testing.T) {
+		var offset int64
+		for range 5 {
+			offset = broker.Publish("devices", "d1", `{"device_id":"d1"}`)
+		}
+		checkTriggers(t, map[string]int{"by-key": 2, "by-field": 1, "all": 4})
+		checkHash(t, "by-key", "0:"+strconv.FormatInt(offset, 10))
+		checkHash(t, "all", "0:"+strconv.FormatInt(offset, 10))
+	})
+
+	t.Run("periodic check without message is unchanged", func(t *testing.T) {
+		result, err := w.CheckNow("user", "by-key", false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Changed {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("paused watcher triggers after resume", func(t *testing.T) {
+		err := w.PauseWatcher("user", "by-key")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		offset := broker.Publish("devices", "d1", `{"device_id":"d1"}`)
+		checkTriggers(t, map[string]int{"by-key": 2, "by-field": 1, "all": 5})
+		err = w.ResumeWatcher("user", "by-key")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result, err := w.CheckNow("user", "by-key", false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !result.Triggered {
+			t.Errorf("%#v", result)
+		}
+		checkTriggers(t, map[string]int{"by-key": 3, "by-field": 1, "all": 5})
+		checkHash(t, "by-key", "0:"+strconv.FormatInt(offset, 10))
+	})
+
+	t.Run("leased watcher is checked after the lease is released", func(t *testing.T) {
+		_, err := database.ClaimLease("other-instance", "by-key", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		broker.Publish("devices", "d1", `{"device_id":"d1"}`)
+		checkTriggers(t, map[string]int{"by-key": 3, "by-field": 1, "all": 6})
+		err = database.ReleaseLease("other-instance", "by-key", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		time.Sleep(time.Second)
+		checkTriggers(t, map[string]int{"by-key": 4, "by-field": 1, "all": 6})
+	})
+
+	t.Run("deleted watcher", func(t *testing.T) {
+		err := w.DeleteWatcher("user", "all")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		broker.Publish("devices", "d1", `{"device_id":"d1"}`)
+		checkTriggers(t, map[string]int{"by-key": 5, "by-field": 1, "all": 6})
+	})
+}
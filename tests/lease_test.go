@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/mongo"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/docker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestConcurrentWatchers(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		m, err := memory.New(configuration.Config{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		testConcurrentWatchers(t, m)
+	})
+
+	t.Run("mongo", func(t *testing.T) {
+		wg := &sync.WaitGroup{}
+		defer wg.Wait()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mongoUrl, err := docker.MongoRs(ctx, wg)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		m, err := mongo.New(configuration.Config{
+			MongoUrl:                     mongoUrl,
+			MongoTable:                   "test",
+			MongoCollectionWatchedEntity: "concurrency",
+		}, ctx)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		testConcurrentWatchers(t, m)
+	})
+}
+
+// testConcurrentWatchers runs several Watcher instances against one database
+// and expects every entity to be checked and triggered exactly once
+func testConcurrentWatchers(t *testing.T, database db.Database) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	entityCount := 200
+	replicaCount := 5
+
+	for i := 0; i < entityCount; i++ {
+		id := strconv.Itoa(i)
+		err := database.Set(model.WatchedEntityInit{
+			Id:       id,
+			UserId:   "user",
+			Interval: "1h",
+			Watch:    model.HttpRequest{Endpoint: id},
+			Trigger:  model.HttpRequest{Endpoint: id},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		//a known last hash ensures that a detected change triggers
+		err = database.UpdateHash(id, "user", "initial")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	c := mocks.NewCountingChecker()
+	c.Delay = 10 * time.Millisecond
+	tr := mocks.NewCountingTrigger()
+	for i := 0; i < replicaCount; i++ {
+		w := watcher.New(configuration.Config{
+			InstanceId: "replica-" + strconv.Itoa(i),
+			BatchSize:  7,
+		}, database, c, tr, mocks.CleanupChecker{})
+		w.StartWithInterval(ctx, wg, 10*time.Millisecond)
+	}
+
+	timeout := time.After(20 * time.Second)
+	for len(tr.Get()) < entityCount {
+		select {
+		case <-timeout:
+			t.Error("timeout", len(tr.Get()))
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	//give replicas the chance to process entities a second time
+	time.Sleep(500 * time.Millisecond)
+
+	checks := c.Get()
+	triggers := tr.Get()
+	for i := 0; i < entityCount; i++ {
+		id := strconv.Itoa(i)
+		if checks[id] != 1 {
+			t.Error("unexpected check count", id, checks[id])
+		}
+		if triggers[id] != 1 {
+			t.Error("unexpected trigger count", id, triggers[id])
+		}
+	}
+}
@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/api"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/docker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestListWatchers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	freePort, err := docker.GetFreePortString()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config := configuration.Config{
+		Database:      "memory",
+		AdvertisedUrl: "http://localhost:" + freePort,
+	}
+
+	db, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	w := watcher.New(config, db, mocks.NewCountingChecker(), mocks.NewCountingTrigger(), mocks.CleanupChecker{})
+	err = api.Start(ctx, config, w)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(100 * time.Millisecond) //wait for the api to listen
+
+	for _, init := range []model.WatchedEntityInit{
+		{Id: "a", UserId: "test-user", Interval: "1h", HashType: "md5"},
+		{Id: "b", UserId: "test-user", Interval: "3h", HashType: "sha256"},
+		{
+			Id:       "c",
+			UserId:   "test-user",
+			Interval: "2h",
+			HashType: "md5",
+			Watch: model.HttpRequest{
+				Method:   "GET",
+				Endpoint: "http://example.com/query",
+				Header:   http.Header{"Authorization": {"Bearer secret"}, "X-Api-Key": {"key1", "key2"}},
+			},
+			Trigger: model.HttpRequest{
+				Method:   "POST",
+				Endpoint: "http://example.com/trigger",
+				Header:   http.Header{"Authorization": {"Bearer secret"}},
+			},
+		},
+		{Id: "d", UserId: "other-user", Interval: "1h", HashType: "md5"},
+	} {
+		err = db.Set(init)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	err = db.UpdateHash("a", "test-user", "hash-a")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	get := func(userId string, path string, result interface{}) (code int, err error) {
+		req, err := http.NewRequest(http.MethodGet, config.AdvertisedUrl+path, nil)
+		if err != nil {
+			return 0, err
+		}
+		token, err := mocks.AuthMock{}.GenerateUserTokenById(userId)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Authorization", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, nil
+		}
+		return resp.StatusCode, json.NewDecoder(resp.Body).Decode(result)
+	}
+
+	listIds := func(t *testing.T, userId string, path string) (ids []string) {
+		list := []model.WatchedEntity{}
+		code, err := get(userId, path, &list)
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+			return nil
+		}
+		ids = []string{}
+		for _, e := range list {
+			ids = append(ids, e.Id)
+		}
+		return ids
+	}
+
+	t.Run("list", func(t *testing.T) {
+		ids := listIds(t, "test-user", "/watcher")
+		if !reflect.DeepEqual(ids, []string{"a", "b", "c"}) {
+			t.Error(ids)
+		}
+	})
+
+	t.Run("list sorted by interval", func(t *testing.T) {
+		ids := listIds(t, "test-user", "/watcher?sort=interval.desc")
+		if !reflect.DeepEqual(ids, []string{"b", "c", "a"}) {
+			t.Error(ids)
+		}
+	})
+
+	t.Run("list with limit and offset", func(t *testing.T) {
+		ids := listIds(t, "test-user", "/watcher?limit=1&offset=1")
+		if !reflect.DeepEqual(ids, []string{"b"}) {
+			t.Error(ids)
+		}
+	})
+
+	t.Run("list of other user", func(t *testing.T) {
+		ids := listIds(t, "other-user", "/watcher")
+		if !reflect.DeepEqual(ids, []string{"d"}) {
+			t.Error(ids)
+		}
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		code, err := get("test-user", "/watcher?sort=watch.asc", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusBadRequest {
+			t.Error(code)
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		code, err := get("test-user", "/watcher?limit=foo", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusBadRequest {
+			t.Error(code)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		result := model.WatchedEntity{}
+		code, err := get("test-user", "/watcher/a", &result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+			return
+		}
+		if result.Id != "a" || result.Interval != "1h" || result.HashType != "md5" || result.LastHash != "hash-a" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("get redacts headers", func(t *testing.T) {
+		result := model.WatchedEntity{}
+		code, err := get("test-user", "/watcher/c", &result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+			return
+		}
+		expectedWatchHeader := http.Header{"Authorization": {watcher.RedactedValue}, "X-Api-Key": {watcher.RedactedValue, watcher.RedactedValue}}
+		if !reflect.DeepEqual(result.Watch.Header, expectedWatchHeader) {
+			t.Error(result.Watch.Header)
+		}
+		expectedTriggerHeader := http.Header{"Authorization": {watcher.RedactedValue}}
+		if !reflect.DeepEqual(result.Trigger.Header, expectedTriggerHeader) {
+			t.Error(result.Trigger.Header)
+		}
+		if result.Watch.Endpoint != "http://example.com/query" || result.Trigger.Endpoint != "http://example.com/trigger" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("redaction does not change stored headers", func(t *testing.T) {
+		stored, err := db.Read("c", "test-user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if stored.Watch.Header.Get("Authorization") != "Bearer secret" {
+			t.Error(stored.Watch.Header)
+		}
+	})
+
+	t.Run("get of other user", func(t *testing.T) {
+		code, err := get("other-user", "/watcher/a", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusNotFound {
+			t.Error(code)
+		}
+	})
+}
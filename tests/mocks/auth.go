@@ -45,7 +45,10 @@ type RealmAccess struct {
 }
 
 func (this AuthMock) GenerateUserTokenById(userid string) (token string, err error) {
-	roles := []string{"user"}
+	return this.GenerateTokenWithRoles(userid, []string{"user"})
+}
+
+func (this AuthMock) GenerateTokenWithRoles(userid string, roles []string) (token string, err error) {
 	claims := KeycloakClaims{
 		RealmAccess{Roles: roles},
 		jwt.StandardClaims{
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+)
+
+// CountingChecker reports every check as changed and counts the checks by watch endpoint.
+// Errors maps watch endpoints to errors returned by Check.
+type CountingChecker struct {
+	mux         sync.Mutex
+	Counts      map[string]int
+	Delay       time.Duration
+	Errors      map[string]error
+	inFlight    int
+	MaxInFlight int
+}
+
+func NewCountingChecker() *CountingChecker {
+	return &CountingChecker{Counts: map[string]int{}, Errors: map[string]error{}}
+}
+
+func (this *CountingChecker) CheckEntity(entity model.WatchedEntity) (result model.CheckResult, err error) {
+	request := entity.Watch
+	this.mux.Lock()
+	this.inFlight = this.inFlight + 1
+	if this.inFlight > this.MaxInFlight {
+		this.MaxInFlight = this.inFlight
+	}
+	this.mux.Unlock()
+
+	time.Sleep(this.Delay)
+
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.inFlight = this.inFlight - 1
+	this.Counts[request.Endpoint] = this.Counts[request.Endpoint] + 1
+	if err = this.Errors[request.Endpoint]; err != nil {
+		return result, err
+	}
+	return model.CheckResult{Changed: true, Hash: "changed", StatusCode: 200}, nil
+}
+
+func (this *CountingChecker) GetMaxInFlight() int {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.MaxInFlight
+}
+
+func (this *CountingChecker) Get() map[string]int {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result := map[string]int{}
+	for k, v := range this.Counts {
+		result[k] = v
+	}
+	return result
+}
+
+// CountingTrigger counts the triggers by trigger endpoint.
+// Errors maps trigger endpoints to errors returned by Run.
+type CountingTrigger struct {
+	mux    sync.Mutex
+	Counts map[string]int
+	Errors map[string]error
+}
+
+func NewCountingTrigger() *CountingTrigger {
+	return &CountingTrigger{Counts: map[string]int{}, Errors: map[string]error{}}
+}
+
+func (this *CountingTrigger) Run(userId string, trigger model.HttpRequest) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.Counts[trigger.Endpoint] = this.Counts[trigger.Endpoint] + 1
+	return this.Errors[trigger.Endpoint]
+}
+
+func (this *CountingTrigger) SetError(endpoint string, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if err == nil {
+		delete(this.Errors, endpoint)
+	} else {
+		this.Errors[endpoint] = err
+	}
+}
+
+func (this *CountingTrigger) Get() map[string]int {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result := map[string]int{}
+	for k, v := range this.Counts {
+		result[k] = v
+	}
+	return result
+}
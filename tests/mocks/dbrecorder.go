@@ -44,9 +44,19 @@ func NewDbRecorder(config configuration.Config, libconfig libconfig.Config, db d
 	}
 }
 
-func (this *DbRecorder) Fetch(max int64) ([]model.WatchedEntity, error) {
+func (this *DbRecorder) Fetch(owner string, max int64) ([]model.WatchedEntity, error) {
 	this.records["Fetch"] = append(this.records["Fetch"], map[string]interface{}{"max": max})
-	return this.db.Fetch(max)
+	return this.db.Fetch(owner, max)
+}
+
+func (this *DbRecorder) ClaimLease(owner string, id string, userId string) (model.WatchedEntity, error) {
+	this.records["ClaimLease"] = append(this.records["ClaimLease"], map[string]interface{}{"id": id, "userId": userId})
+	return this.db.ClaimLease(owner, id, userId)
+}
+
+func (this *DbRecorder) ReleaseLease(owner string, id string, userId string) error {
+	this.records["ReleaseLease"] = append(this.records["ReleaseLease"], map[string]interface{}{"id": id, "userId": userId})
+	return this.db.ReleaseLease(owner, id, userId)
 }
 
 func (this *DbRecorder) UpdateHash(id string, userId string, hash string) error {
@@ -72,6 +82,81 @@ func (this *DbRecorder) Delete(id string, userId string) error {
 	return this.db.Delete(id, userId)
 }
 
+func (this *DbRecorder) AddHistoryEntry(entry model.HistoryEntry) error {
+	this.records["AddHistoryEntry"] = append(this.records["AddHistoryEntry"], map[string]interface{}{"entry": entry})
+	return this.db.AddHistoryEntry(entry)
+}
+
+func (this *DbRecorder) ListHistory(id string, userId string, limit int64) ([]model.HistoryEntry, error) {
+	this.records["ListHistory"] = append(this.records["ListHistory"], map[string]interface{}{"id": id, "userId": userId, "limit": limit})
+	return this.db.ListHistory(id, userId, limit)
+}
+
+func (this *DbRecorder) SetPendingHash(id string, userId string, hash string) error {
+	this.records["SetPendingHash"] = append(this.records["SetPendingHash"], map[string]interface{}{"id": id, "userId": userId, "hash": hash})
+	return this.db.SetPendingHash(id, userId, hash)
+}
+
+func (this *DbRecorder) PromotePendingHash(id string, userId string, result model.CheckResult, outbox *model.PendingTrigger) error {
+	this.records["PromotePendingHash"] = append(this.records["PromotePendingHash"], map[string]interface{}{"id": id, "userId": userId, "result": result, "outbox": outbox})
+	return this.db.PromotePendingHash(id, userId, result, outbox)
+}
+
+func (this *DbRecorder) UpdateValidators(id string, userId string, etag string, lastModified string) error {
+	this.records["UpdateValidators"] = append(this.records["UpdateValidators"], map[string]interface{}{"id": id, "userId": userId, "etag": etag, "lastModified": lastModified})
+	return this.db.UpdateValidators(id, userId, etag, lastModified)
+}
+
+func (this *DbRecorder) UpdateFailureState(id string, userId string, state model.FailureState, timestampOfNextCheck int64) error {
+	this.records["UpdateFailureState"] = append(this.records["UpdateFailureState"], map[string]interface{}{"id": id, "userId": userId, "state": state, "timestampOfNextCheck": timestampOfNextCheck})
+	return this.db.UpdateFailureState(id, userId, state, timestampOfNextCheck)
+}
+
+func (this *DbRecorder) SetPaused(id string, userId string, paused bool) error {
+	this.records["SetPaused"] = append(this.records["SetPaused"], map[string]interface{}{"id": id, "userId": userId, "paused": paused})
+	return this.db.SetPaused(id, userId, paused)
+}
+
+func (this *DbRecorder) ListByUser(userId string, query db.QueryOptions) ([]model.WatchedEntity, error) {
+	this.records["ListByUser"] = append(this.records["ListByUser"], map[string]interface{}{"userId": userId, "limit": query.GetLimit(), "offset": query.GetOffset(), "sort": query.GetSort()})
+	return this.db.ListByUser(userId, query)
+}
+
+func (this *DbRecorder) ListBySourceType(sourceType string) ([]model.WatchedEntity, error) {
+	this.records["ListBySourceType"] = append(this.records["ListBySourceType"], map[string]interface{}{"sourceType": sourceType})
+	return this.db.ListBySourceType(sourceType)
+}
+
+func (this *DbRecorder) AddPendingTrigger(trigger model.PendingTrigger) error {
+	this.records["AddPendingTrigger"] = append(this.records["AddPendingTrigger"], map[string]interface{}{"trigger": trigger})
+	return this.db.AddPendingTrigger(trigger)
+}
+
+func (this *DbRecorder) FetchPendingTriggers(max int64) ([]model.PendingTrigger, error) {
+	this.records["FetchPendingTriggers"] = append(this.records["FetchPendingTriggers"], map[string]interface{}{"max": max})
+	return this.db.FetchPendingTriggers(max)
+}
+
+func (this *DbRecorder) UpdatePendingTrigger(trigger model.PendingTrigger) error {
+	this.records["UpdatePendingTrigger"] = append(this.records["UpdatePendingTrigger"], map[string]interface{}{"trigger": trigger})
+	return this.db.UpdatePendingTrigger(trigger)
+}
+
+func (this *DbRecorder) RemovePendingTrigger(id string) error {
+	this.records["RemovePendingTrigger"] = append(this.records["RemovePendingTrigger"], map[string]interface{}{"id": id})
+	return this.db.RemovePendingTrigger(id)
+}
+
+func (this *DbRecorder) ReadPendingTrigger(id string, userId string) (model.PendingTrigger, error) {
+	this.records["ReadPendingTrigger"] = append(this.records["ReadPendingTrigger"], map[string]interface{}{"id": id, "userId": userId})
+	return this.db.ReadPendingTrigger(id, userId)
+}
+
+func (this *DbRecorder) ListDeadLetters(userId string) ([]model.PendingTrigger, error) {
+	this.records["ListDeadLetters"] = append(this.records["ListDeadLetters"], map[string]interface{}{"userId": userId})
+	return this.db.ListDeadLetters(userId)
+}
+
 func (this *DbRecorder) CheckExpectedRequestsFromFileLocation(fileLocation string) error {
 	fileContent, err := os.ReadFile(fileLocation)
 	if err != nil {
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+)
+
+// FailingDb wraps a db.Database and lets SetPendingHash and PromotePendingHash fail,
+// to simulate failures between the steps of a hash update
+type FailingDb struct {
+	db.Database
+	mux    sync.Mutex
+	errors map[string]error
+}
+
+func NewFailingDb(database db.Database) *FailingDb {
+	return &FailingDb{Database: database, errors: map[string]error{}}
+}
+
+// SetError lets the named method fail with err; a nil err removes the failure
+func (this *FailingDb) SetError(method string, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if err == nil {
+		delete(this.errors, method)
+	} else {
+		this.errors[method] = err
+	}
+}
+
+func (this *FailingDb) getError(method string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.errors[method]
+}
+
+func (this *FailingDb) SetPendingHash(id string, userId string, hash string) error {
+	if err := this.getError("SetPendingHash"); err != nil {
+		return err
+	}
+	return this.Database.SetPendingHash(id, userId, hash)
+}
+
+func (this *FailingDb) PromotePendingHash(id string, userId string, result model.CheckResult, outbox *model.PendingTrigger) error {
+	if err := this.getError("PromotePendingHash"); err != nil {
+		return err
+	}
+	return this.Database.PromotePendingHash(id, userId, result, outbox)
+}
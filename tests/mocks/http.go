@@ -18,6 +18,8 @@ package mocks
 
 import (
 	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
 	"io"
 	"net/http"
@@ -68,3 +70,41 @@ func StartTestHttpMock(ctx context.Context, wg *sync.WaitGroup, responseList []H
 	url = server.URL
 	return url, mux, requests
 }
+
+// SetHttpMockEntity stores the entity with watch and trigger requests sent to a new http mock.
+// the endpoints of entity.Watch and entity.Trigger are used as paths of the mock url;
+// responses are used in request order by watch and trigger requests.
+func SetHttpMockEntity(ctx context.Context, wg *sync.WaitGroup, database db.Database, entity model.WatchedEntityInit, responses []HttpMockResponse) (mux *sync.Mutex, requests *[]model.HttpRequest, err error) {
+	targetUrl, mux, requests := StartTestHttpMock(ctx, wg, responses)
+	if entity.UserId == "" {
+		entity.UserId = "user"
+	}
+	if entity.Interval == "" {
+		entity.Interval = "1h"
+	}
+	if entity.Watch.Method == "" {
+		entity.Watch.Method = http.MethodGet
+	}
+	if entity.Trigger.Method == "" {
+		entity.Trigger.Method = http.MethodPost
+	}
+	entity.Watch.Endpoint = targetUrl + entity.Watch.Endpoint
+	entity.Trigger.Endpoint = targetUrl + entity.Trigger.Endpoint
+	err = database.Set(entity)
+	return mux, requests, err
+}
+
+// GetTriggerInputs returns the parameter values of a trigger request body by parameter id
+func GetTriggerInputs(request model.HttpRequest) (result map[string]interface{}, err error) {
+	parameters := []map[string]interface{}{}
+	err = json.Unmarshal(request.Body, &parameters)
+	if err != nil {
+		return nil, err
+	}
+	result = map[string]interface{}{}
+	for _, parameter := range parameters {
+		id, _ := parameter["id"].(string)
+		result[id] = parameter["value"]
+	}
+	return result, nil
+}
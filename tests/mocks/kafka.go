@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source/kafka"
+)
+
+// KafkaMock is an in-process stand-in for kafka with a single partition per topic;
+// readers receive the messages published after their creation
+type KafkaMock struct {
+	mux     sync.Mutex
+	offsets map[string]int64
+	readers map[string][]*kafkaMockReader
+}
+
+func NewKafkaMock() *KafkaMock {
+	return &KafkaMock{offsets: map[string]int64{}, readers: map[string][]*kafkaMockReader{}}
+}
+
+func (this *KafkaMock) NewReader(topic string) (kafka.Reader, error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	reader := &kafkaMockReader{messages: make(chan kafka.Message, 100), closed: make(chan struct{})}
+	this.readers[topic] = append(this.readers[topic], reader)
+	return reader, nil
+}
+
+// Readers returns the count of readers created for the topic
+func (this *KafkaMock) Readers(topic string) int {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return len(this.readers[topic])
+}
+
+// Publish returns the offset of the message
+func (this *KafkaMock) Publish(topic string, key string, value string) int64 {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	offset := this.offsets[topic]
+	this.offsets[topic] = offset + 1
+	msg := kafka.Message{Topic: topic, Partition: 0, Offset: offset, Key: []byte(key), Value: []byte(value)}
+	for _, reader := range this.readers[topic] {
+		select {
+		case reader.messages <- msg:
+		case <-reader.closed:
+		}
+	}
+	return offset
+}
+
+type kafkaMockReader struct {
+	messages  chan kafka.Message
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (this *kafkaMockReader) ReadMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	case msg := <-this.messages:
+		return msg, nil
+	}
+}
+
+func (this *kafkaMockReader) Close() error {
+	this.closeOnce.Do(func() {
+		close(this.closed)
+	})
+	return nil
+}
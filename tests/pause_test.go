@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/api"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/docker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestPauseResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	freePort, err := docker.GetFreePortString()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config := configuration.Config{
+		Database:      "memory",
+		AdvertisedUrl: "http://localhost:" + freePort,
+	}
+
+	db, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	c := mocks.NewCountingChecker()
+	tr := mocks.NewCountingTrigger()
+	w := watcher.New(config, db, c, tr, mocks.CleanupChecker{})
+	err = api.Start(ctx, config, w)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(100 * time.Millisecond) //wait for the api to listen
+
+	err = db.Set(model.WatchedEntityInit{
+		Id:       "w1",
+		UserId:   "test-user",
+		Interval: "1ms",
+		Watch:    model.HttpRequest{Endpoint: "watch"},
+		Trigger:  model.HttpRequest{Endpoint: "trigger"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = db.UpdateHash("w1", "test-user", "initial")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	post := func(userId string, path string) (code int, err error) {
+		req, err := http.NewRequest(http.MethodPost, config.AdvertisedUrl+path, nil)
+		if err != nil {
+			return 0, err
+		}
+		token, err := mocks.AuthMock{}.GenerateUserTokenById(userId)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Authorization", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	t.Run("pause", func(t *testing.T) {
+		code, err := post("test-user", "/watcher/w1/pause")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+		}
+	})
+
+	t.Run("paused watcher is not checked", func(t *testing.T) {
+		count, err := w.Run(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if count != 0 || c.Get()["watch"] != 0 {
+			t.Error(count, c.Get())
+		}
+	})
+
+	t.Run("other user can not resume", func(t *testing.T) {
+		code, err := post("other-user", "/watcher/w1/resume")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusNotFound {
+			t.Error(code)
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		code, err := post("test-user", "/watcher/w1/resume")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+		}
+	})
+
+	t.Run("change while paused triggers after resume", func(t *testing.T) {
+		count, err := w.Run(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if count != 1 || c.Get()["watch"] != 1 || tr.Get()["trigger"] != 1 {
+			t.Error(count, c.Get(), tr.Get())
+		}
+	})
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/trigger"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestPredicate(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config := configuration.Config{}
+	db, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	c, err := checker.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tr, err := trigger.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	w := watcher.New(config, db, c, tr, mocks.CleanupChecker{})
+
+	battery := func(value int) mocks.HttpMockResponse {
+		return mocks.HttpMockResponse{Code: 200, Payload: []byte(fmt.Sprintf(`{"battery":%v}`, value))}
+	}
+	triggerResponse := mocks.HttpMockResponse{Code: 200}
+
+	type step struct {
+		predicate bool
+		triggered bool
+	}
+
+	//creates a watcher with its own http mock; responses are used in request order by watch and trigger requests
+	run := func(t *testing.T, id string, mode string, responses []mocks.HttpMockResponse, steps []step) {
+		mux, requests, err := mocks.SetHttpMockEntity(ctx, wg, db, model.WatchedEntityInit{
+			Id:       id,
+			HashType: checker.HASH_TYPE_PREDICATE,
+			HashOptions: model.HashOptions{
+				Predicate:     "battery < 20",
+				PredicateMode: mode,
+			},
+			Watch:   model.HttpRequest{Endpoint: "/query"},
+			Trigger: model.HttpRequest{Endpoint: "/start"},
+		}, responses)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for i, step := range steps {
+			result, err := w.CheckNow("user", id, false)
+			if err != nil {
+				t.Error(i, err)
+				return
+			}
+			if result.Predicate == nil || *result.Predicate != step.predicate || result.Triggered != step.triggered {
+				t.Errorf("%v: %#v", i, result)
+			}
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		if len(*requests) != len(responses) {
+			t.Errorf("%#v", *requests)
+		}
+	}
+
+	t.Run("edge", func(t *testing.T) {
+		run(t, "edge", checker.PREDICATE_MODE_EDGE, []mocks.HttpMockResponse{
+			battery(50),
+			battery(15), triggerResponse,
+			battery(10),
+			battery(50),
+			battery(5), triggerResponse,
+		}, []step{
+			{predicate: false, triggered: false},
+			{predicate: true, triggered: true},
+			{predicate: true, triggered: false},
+			{predicate: false, triggered: false},
+			{predicate: true, triggered: true},
+		})
+	})
+
+	t.Run("default mode is edge", func(t *testing.T) {
+		run(t, "default", "", []mocks.HttpMockResponse{
+			battery(50),
+			battery(15), triggerResponse,
+			battery(10),
+		}, []step{
+			{predicate: false, triggered: false},
+			{predicate: true, triggered: true},
+			{predicate: true, triggered: false},
+		})
+	})
+
+	t.Run("edge with initial true state", func(t *testing.T) {
+		run(t, "edge-initial", checker.PREDICATE_MODE_EDGE, []mocks.HttpMockResponse{
+			battery(15), triggerResponse,
+			battery(10),
+			battery(50),
+			battery(5), triggerResponse,
+		}, []step{
+			{predicate: true, triggered: true},
+			{predicate: true, triggered: false},
+			{predicate: false, triggered: false},
+			{predicate: true, triggered: true},
+		})
+	})
+
+	t.Run("level", func(t *testing.T) {
+		run(t, "level", checker.PREDICATE_MODE_LEVEL, []mocks.HttpMockResponse{
+			battery(15), triggerResponse,
+			battery(10), triggerResponse,
+			battery(12), triggerResponse,
+			battery(50),
+			battery(60),
+			battery(1), triggerResponse,
+		}, []step{
+			{predicate: true, triggered: true},
+			{predicate: true, triggered: true},
+			{predicate: true, triggered: true},
+			{predicate: false, triggered: false},
+			{predicate: false, triggered: false},
+			{predicate: true, triggered: true},
+		})
+	})
+}
@@ -0,0 +1,295 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/api"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/ratelimit"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/trigger"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Run("token bucket by host", func(t *testing.T) {
+		mux := sync.Mutex{}
+		now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+		clock := func() time.Time {
+			mux.Lock()
+			defer mux.Unlock()
+			return now
+		}
+		advance := func(d time.Duration) {
+			mux.Lock()
+			defer mux.Unlock()
+			now = now.Add(d)
+		}
+		limiter := ratelimit.NewWithClock(ratelimit.Limit{Rate: 1, Burst: 2}, map[string]ratelimit.Limit{
+			"unlimited.example": {Rate: 0},
+			"fast.example":      {Rate: 100, Burst: 2},
+		}, clock)
+
+		//a canceled context returns immediately for throttled requests
+		canceled, cancel := context.WithCancel(context.Background())
+		cancel()
+		throttled := func(endpoint string) bool {
+			return limiter.Wait(canceled, endpoint) != nil
+		}
+
+		if throttled("http://a.example/1") || throttled("http://A.example:8080/2") {
+			t.Error("burst should not be throttled")
+		}
+		if !throttled("http://a.example/3") {
+			t.Error("expected throttled request")
+		}
+		if throttled("http://b.example/1") {
+			t.Error("hosts should have separate buckets")
+		}
+		advance(time.Second)
+		if throttled("http://a.example/3") {
+			t.Error("expected refilled token")
+		}
+		if !throttled("http://a.example/4") {
+			t.Error("expected throttled request")
+		}
+		for range 10 {
+			if throttled("http://unlimited.example/") {
+				t.Error("override without rate should not be throttled")
+			}
+		}
+		throttled("http://fast.example/")
+		throttled("http://fast.example/")
+		if !throttled("http://fast.example/") {
+			t.Error("expected throttled request")
+		}
+		advance(10 * time.Millisecond)
+		if throttled("http://fast.example/") {
+			t.Error("expected refilled token of override")
+		}
+	})
+
+	t.Run("throttled beyond the deadline", func(t *testing.T) {
+		limiter := ratelimit.NewWithClock(ratelimit.Limit{Rate: 1, Burst: 1}, nil, time.Now)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := limiter.Wait(ctx, "http://deadline.example/")
+		if err != nil {
+			t.Error(err)
+		}
+		start := time.Now()
+		err = limiter.Wait(ctx, "http://deadline.example/")
+		if !errors.Is(err, ratelimit.ErrThrottled) {
+			t.Error(err)
+		}
+		if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+			t.Error("expected rejection without waiting", elapsed)
+		}
+	})
+
+	t.Run("refilled buckets are removed", func(t *testing.T) {
+		mux := sync.Mutex{}
+		now := time.Now()
+		clock := func() time.Time {
+			mux.Lock()
+			defer mux.Unlock()
+			return now
+		}
+		limiter := ratelimit.NewWithClock(ratelimit.Limit{Rate: 1, Burst: 5}, nil, clock)
+		for i := range 50 {
+			limiter.Wait(context.Background(), "http://"+strconv.Itoa(i)+".sweep.example/")
+		}
+		if limiter.Buckets() != 50 {
+			t.Error(limiter.Buckets())
+		}
+		mux.Lock()
+		now = now.Add(2 * time.Minute)
+		mux.Unlock()
+		limiter.Wait(context.Background(), "http://other.sweep.example/")
+		if limiter.Buckets() != 1 {
+			t.Error(limiter.Buckets())
+		}
+	})
+
+	t.Run("metrics endpoint is limited to admins", func(t *testing.T) {
+		apiServer := httptest.NewServer(api.GetRouter(configuration.Config{}, nil))
+		defer apiServer.Close()
+		get := func(roles []string) (code int, result map[string]interface{}) {
+			req, err := http.NewRequest(http.MethodGet, apiServer.URL+"/metrics", nil)
+			if err != nil {
+				t.Error(err)
+				return 0, nil
+			}
+			if roles != nil {
+				token, err := mocks.AuthMock{}.GenerateTokenWithRoles("user", roles)
+				if err != nil {
+					t.Error(err)
+					return 0, nil
+				}
+				req.Header.Set("Authorization", token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Error(err)
+				return 0, nil
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				err = json.NewDecoder(resp.Body).Decode(&result)
+				if err != nil {
+					t.Error(err)
+				}
+			}
+			return resp.StatusCode, result
+		}
+		if code, _ := get(nil); code != http.StatusUnauthorized {
+			t.Error(code)
+		}
+		if code, _ := get([]string{"user"}); code != http.StatusForbidden {
+			t.Error(code)
+		}
+		code, result := get([]string{"user", "admin"})
+		if code != http.StatusOK {
+			t.Error(code)
+			return
+		}
+		if _, ok := result["rate_limit"]; !ok || len(result) != 1 {
+			t.Error(result)
+		}
+	})
+
+	t.Run("invalid override", func(t *testing.T) {
+		_, err := ratelimit.New(configuration.Config{RateLimit: 1, RateLimitOverrides: map[string]string{"a.example": "fast"}})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+		writer.Write([]byte(time.Now().String()))
+	}))
+	defer server.Close()
+	host := url.URL{Host: server.Listener.Addr().String()}
+
+	config := configuration.Config{RateLimit: 10, RateLimitBurst: 1, RateLimitOverrides: map[string]string{"localhost": "0"}}
+	limiter, err := ratelimit.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("throttled checks are delayed", func(t *testing.T) {
+		c, err := checker.NewWithLimiter(config, mocks.AuthMock{}, limiter)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		start := time.Now()
+		for range 3 {
+			_, _, err := c.Check("user", model.HttpRequest{Method: "GET", Endpoint: server.URL}, checker.HASH_TYPE_MD5, "")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+			t.Error("expected delayed checks", elapsed)
+		}
+	})
+
+	t.Run("trigger shares the limit of the host", func(t *testing.T) {
+		tr, err := trigger.NewWithLimiter(config, mocks.AuthMock{}, limiter)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		start := time.Now()
+		err = tr.Run("user", model.HttpRequest{Method: "POST", Endpoint: server.URL})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Error("expected delayed trigger", elapsed)
+		}
+	})
+
+	t.Run("override disables the limit", func(t *testing.T) {
+		tr, err := trigger.NewWithLimiter(config, mocks.AuthMock{}, limiter)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		start := time.Now()
+		for range 5 {
+			err = tr.Run("user", model.HttpRequest{Method: "POST", Endpoint: "http://localhost:" + host.Port()})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+			t.Error("unexpected delay", elapsed)
+		}
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		hostMetrics, ok := expvar.Get("rate_limit").(*expvar.Map).Get(host.Hostname()).(*expvar.Map)
+		if !ok {
+			t.Error("missing metrics of host")
+			return
+		}
+		requests, _ := hostMetrics.Get("requests").(*expvar.Int)
+		throttled, _ := hostMetrics.Get("throttled").(*expvar.Int)
+		wait, _ := hostMetrics.Get("wait_seconds").(*expvar.Float)
+		if requests == nil || throttled == nil || wait == nil || requests.Value() < 4 || throttled.Value() < 3 || wait.Value() <= 0 {
+			t.Error(hostMetrics.String())
+		}
+	})
+
+	//fills the published metrics, therefore the last test
+	t.Run("metrics hosts are limited", func(t *testing.T) {
+		limiter := ratelimit.NewWithClock(ratelimit.Limit{Rate: 1000, Burst: 1}, nil, time.Now)
+		for i := range ratelimit.MaxMetricsHosts + 50 {
+			limiter.Wait(context.Background(), "http://"+strconv.Itoa(i)+".metrics.example/")
+		}
+		hosts := 0
+		expvar.Get("rate_limit").(*expvar.Map).Do(func(expvar.KeyValue) {
+			hosts++
+		})
+		if hosts > ratelimit.MaxMetricsHosts+1 {
+			t.Error(hosts)
+		}
+		if expvar.Get("rate_limit").(*expvar.Map).Get(ratelimit.OtherHosts) == nil {
+			t.Error("missing metrics of other hosts")
+		}
+	})
+}
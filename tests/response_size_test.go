@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+const streamChunkSize = 1 << 20
+
+var streamChunk = bytes.Repeat([]byte("0123456789abcdef"), streamChunkSize/16)
+
+// streamingServer writes ?size bytes in chunks without buffering them; ?content_length=true announces the size
+func startStreamingServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		size, err := strconv.ParseInt(request.URL.Query().Get("size"), 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if request.URL.Query().Get("content_length") == "true" {
+			writer.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+		}
+		for written := int64(0); written < size; written = written + streamChunkSize {
+			_, err = writer.Write(streamChunk[:min(streamChunkSize, size-written)])
+			if err != nil {
+				return //client closed the connection
+			}
+		}
+	}))
+}
+
+func TestResponseSizeLimit(t *testing.T) {
+	server := startStreamingServer()
+	defer server.Close()
+
+	const gigabyte = int64(1) << 30
+
+	newChecker := func(t *testing.T, maxResponseSize int64) *checker.Checker {
+		c, err := checker.New(configuration.Config{MaxResponseSize: maxResponseSize}, mocks.AuthMock{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	check := func(c *checker.Checker, hashType string, size int64, contentLength bool) (hash string, err error) {
+		_, hash, err = c.Check("user", model.HttpRequest{
+			Method:   "GET",
+			Endpoint: fmt.Sprintf("%v/?size=%v&content_length=%v", server.URL, size, contentLength),
+		}, hashType, "")
+		return hash, err
+	}
+
+	for _, hashType := range []string{checker.HASH_TYPE_MD5, checker.HASH_TYPE_SHA256, checker.HASH_TYPE_JSON_CANONICAL, checker.HASH_TYPE_DEVICEIDS} {
+		t.Run("oversized stream "+hashType, func(t *testing.T) {
+			start := time.Now()
+			_, err := check(newChecker(t, 1<<20), hashType, 4*gigabyte, false)
+			if !errors.Is(err, checker.ErrResponseTooLarge) {
+				t.Error(err)
+			}
+			if duration := time.Since(start); duration > 2*time.Second {
+				t.Error("oversized response was not aborted", duration)
+			}
+		})
+	}
+
+	t.Run("oversized content length", func(t *testing.T) {
+		_, err := check(newChecker(t, 1<<20), checker.HASH_TYPE_MD5, 4*gigabyte, true)
+		if !errors.Is(err, checker.ErrResponseTooLarge) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		_, err := check(newChecker(t, 0), checker.HASH_TYPE_MD5, gigabyte, false)
+		if !errors.Is(err, checker.ErrResponseTooLarge) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("response of max size", func(t *testing.T) {
+		_, err := check(newChecker(t, 3<<20), checker.HASH_TYPE_MD5, 3<<20, true)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("streaming hash does not buffer", func(t *testing.T) {
+		const size = 256 << 20
+		expected := md5.New()
+		for written := 0; written < size; written = written + streamChunkSize {
+			expected.Write(streamChunk)
+		}
+
+		before := runtime.MemStats{}
+		runtime.ReadMemStats(&before)
+		hash, err := check(newChecker(t, gigabyte), checker.HASH_TYPE_MD5, size, false)
+		after := runtime.MemStats{}
+		runtime.ReadMemStats(&after)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if hash != fmt.Sprintf("%x", expected.Sum(nil)) {
+			t.Error(hash)
+		}
+		if allocated := after.TotalAlloc - before.TotalAlloc; allocated > size/8 {
+			t.Error("streaming hash allocated", allocated)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestRunConcurrencyLimit(t *testing.T) {
+	config := configuration.Config{MaxConcurrentChecks: 3}
+	database, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	errFoo := errors.New("foo error")
+	errBar := errors.New("bar error")
+
+	for i := 0; i < 20; i++ {
+		id := strconv.Itoa(i)
+		err = database.Set(model.WatchedEntityInit{
+			Id:       id,
+			UserId:   "user",
+			Interval: "1h",
+			Watch:    model.HttpRequest{Endpoint: id},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	c := mocks.NewCountingChecker()
+	c.Delay = 20 * time.Millisecond
+	c.Errors["3"] = errFoo
+	c.Errors["7"] = errBar
+
+	w := watcher.New(config, database, c, mocks.NewCountingTrigger(), mocks.CleanupChecker{})
+	count, err := w.Run(100)
+	if count != 20 {
+		t.Error(count)
+	}
+
+	t.Run("errors of all entities are returned", func(t *testing.T) {
+		if !errors.Is(err, errFoo) || !errors.Is(err, errBar) {
+			t.Error(err)
+			return
+		}
+		if !strings.Contains(err.Error(), "watcher 3 of user user") || !strings.Contains(err.Error(), "watcher 7 of user user") {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("every entity is checked", func(t *testing.T) {
+		checks := c.Get()
+		for i := 0; i < 20; i++ {
+			if checks[strconv.Itoa(i)] != 1 {
+				t.Error(i, checks[strconv.Itoa(i)])
+			}
+		}
+	})
+
+	t.Run("concurrency is limited", func(t *testing.T) {
+		if max := c.GetMaxInFlight(); max != 3 {
+			t.Error(max)
+		}
+	})
+}
+
+func TestRunConcurrencyDefault(t *testing.T) {
+	config := configuration.Config{}
+	database, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 20; i++ {
+		id := strconv.Itoa(i)
+		err = database.Set(model.WatchedEntityInit{
+			Id:       id,
+			UserId:   "user",
+			Interval: "1h",
+			Watch:    model.HttpRequest{Endpoint: id},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	c := mocks.NewCountingChecker()
+	c.Delay = 20 * time.Millisecond
+	w := watcher.New(config, database, c, mocks.NewCountingTrigger(), mocks.CleanupChecker{})
+	count, err := w.Run(100)
+	if err != nil || count != 20 {
+		t.Error(count, err)
+		return
+	}
+	if max := c.GetMaxInFlight(); max != 10 {
+		t.Error(max)
+	}
+}
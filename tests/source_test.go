@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+type constantSourceConfig struct {
+	Value string `json:"value"`
+}
+
+// constantSource is a non http source that returns the configured value as hash
+type constantSource struct{}
+
+func (this constantSource) CheckEntity(entity model.WatchedEntity) (result model.CheckResult, err error) {
+	config, err := source.DecodeConfig[constantSourceConfig](entity.SourceConfig)
+	if err != nil {
+		return result, err
+	}
+	return model.CheckResult{Changed: config.Value != entity.LastHash, Hash: config.Value}, nil
+}
+
+func (this constantSource) Validate(entity model.WatchedEntityInit) error {
+	_, err := source.DecodeConfig[constantSourceConfig](entity.SourceConfig)
+	return err
+}
+
+func TestSourceRegistry(t *testing.T) {
+	config := configuration.Config{}
+	database, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	registry, err := pkg.NewSources(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = registry.Register("constant", constantSource{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tr := mocks.NewCountingTrigger()
+	w := watcher.New(config, database, registry, tr, mocks.CleanupChecker{})
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte("http"))
+	}))
+	defer httpServer.Close()
+
+	t.Run("source types", func(t *testing.T) {
+		if types := registry.SourceTypes(); !slices.Equal(types, []string{"constant", model.SOURCE_TYPE_HTTP}) {
+			t.Error(types)
+		}
+		err := registry.Register("constant", constantSource{})
+		if !errors.Is(err, source.ErrAlreadyRegistered) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("unknown source type is rejected", func(t *testing.T) {
+		err := w.Set(model.WatchedEntityInit{Id: "unknown", UserId: "user", Interval: "1h", SourceType: "foo"})
+		if !errors.Is(err, source.ErrUnknownSourceType) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("invalid source config is rejected", func(t *testing.T) {
+		err := w.Set(model.WatchedEntityInit{Id: "invalid", UserId: "user", Interval: "1h", SourceType: "constant", SourceConfig: json.RawMessage(`{"foo":"bar"}`)})
+		if err == nil {
+			t.Error("expected error")
+		}
+		err = w.Set(model.WatchedEntityInit{Id: "invalid", UserId: "user", Interval: "1h", SourceType: "constant"})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("constant source", func(t *testing.T) {
+		err := w.Set(model.WatchedEntityInit{
+			Id:           "constant",
+			UserId:       "user",
+			Interval:     "1h",
+			SourceType:   "constant",
+			SourceConfig: json.RawMessage(`{"value":"foo"}`),
+			Trigger:      model.HttpRequest{Endpoint: "constant"},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result, err := w.CheckNow("user", "constant", false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Hash != "foo" || !result.Changed {
+			t.Errorf("%#v", result)
+		}
+		result, err = w.CheckNow("user", "constant", false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Changed {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("empty source type is http", func(t *testing.T) {
+		err := w.Set(model.WatchedEntityInit{
+			Id:       "legacy",
+			UserId:   "user",
+			Interval: "1h",
+			HashType: "md5",
+			Watch:    model.HttpRequest{Method: "GET", Endpoint: httpServer.URL},
+			Trigger:  model.HttpRequest{Endpoint: "legacy"},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result, err := w.CheckNow("user", "legacy", false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.StatusCode != http.StatusOK || !result.Changed {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("invalid http timeout is rejected", func(t *testing.T) {
+		err := w.Set(model.WatchedEntityInit{
+			Id:         "timeout",
+			UserId:     "user",
+			Interval:   "1h",
+			SourceType: model.SOURCE_TYPE_HTTP,
+			Watch:      model.HttpRequest{Method: "GET", Endpoint: httpServer.URL, Timeout: "foo"},
+		})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
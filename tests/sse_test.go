@@ -0,0 +1,361 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source/sse"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+// sseServer writes the sent strings to the open stream; close ends the current stream
+type sseServer struct {
+	mux          sync.Mutex
+	connections  int
+	open         int
+	reject       int
+	lastEventIds []string
+	events       chan string
+	close        chan struct{}
+}
+
+func newSseServer() *sseServer {
+	return &sseServer{events: make(chan string), close: make(chan struct{})}
+}
+
+func (this *sseServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	this.mux.Lock()
+	this.connections = this.connections + 1
+	this.lastEventIds = append(this.lastEventIds, request.Header.Get("Last-Event-ID"))
+	if this.reject > 0 {
+		this.reject = this.reject - 1
+		this.mux.Unlock()
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	this.open = this.open + 1
+	this.mux.Unlock()
+	defer func() {
+		this.mux.Lock()
+		this.open = this.open - 1
+		this.mux.Unlock()
+	}()
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.WriteHeader(http.StatusOK)
+	writer.(http.Flusher).Flush()
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case <-this.close:
+			return
+		case event := <-this.events:
+			writer.Write([]byte(event))
+			writer.(http.Flusher).Flush()
+		}
+	}
+}
+
+func (this *sseServer) send(t *testing.T, event string) {
+	select {
+	case this.events <- event:
+	case <-time.After(2 * time.Second):
+		t.Error("no open stream for", event)
+	}
+}
+
+func (this *sseServer) state() (connections int, open int, lastEventIds []string) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.connections, this.open, append([]string{}, this.lastEventIds...)
+}
+
+func TestSseSource(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config := configuration.Config{SseReconnectBackoff: "10ms", SseMaxReconnectBackoff: "50ms", SseResyncInterval: "50ms"}
+	database, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	registry := source.NewRegistry()
+	err = registry.Register(sse.SOURCE_TYPE, sse.NewWithClient(config, mocks.AuthMock{}, &http.Client{}))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = registry.Register("isolated", sse.New(config, mocks.AuthMock{}))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tr := mocks.NewCountingTrigger()
+	w := watcher.New(config, database, registry, tr, mocks.CleanupChecker{})
+
+	//existing watchers are connected on start, without a previous check
+	restoredServer := newSseServer()
+	restoredHttpServer := httptest.NewServer(restoredServer)
+	defer restoredHttpServer.Close()
+	defer w.DeleteWatcher("user", "restored") //closes the stream before the server
+	err = database.Set(model.WatchedEntityInit{
+		Id:           "restored",
+		UserId:       "user",
+		Interval:     "1h",
+		HashType:     checker.HASH_TYPE_MD5,
+		SourceType:   sse.SOURCE_TYPE,
+		SourceConfig: json.RawMessage(`{"endpoint":"` + restoredHttpServer.URL + `"}`),
+		Trigger:      model.HttpRequest{Endpoint: "restored"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = database.UpdateHash("restored", "user", sse.HASH_NO_EVENT)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = registry.Start(ctx, wg, w)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	set := func(t *testing.T, id string, sourceType string, sourceConfig sse.Config) {
+		raw, err := json.Marshal(sourceConfig)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = w.Set(model.WatchedEntityInit{
+			Id:           id,
+			UserId:       "user",
+			Interval:     "1h",
+			HashType:     checker.HASH_TYPE_MD5,
+			SourceType:   sourceType,
+			SourceConfig: raw,
+			Trigger:      model.HttpRequest{Endpoint: id},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result, err := w.CheckNow("user", id, false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Hash != sse.HASH_NO_EVENT {
+			t.Errorf("%#v", result)
+		}
+	}
+
+	checkTriggers := func(t *testing.T, id string, expected int) {
+		time.Sleep(200 * time.Millisecond)
+		if actual := tr.Get()[id]; actual != expected {
+			t.Errorf("%v: %v != %v", id, actual, expected)
+		}
+	}
+
+	checkHash := func(t *testing.T, id string, payload string) {
+		expected, err := checker.CheckPayload(model.WatchedEntity{WatchedEntityInit: model.WatchedEntityInit{HashType: checker.HASH_TYPE_MD5}}, []byte(payload))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		entity, err := database.Read(id, "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if entity.LastHash != expected.Hash {
+			t.Errorf("%v: %v != %v", id, entity.LastHash, expected.Hash)
+		}
+	}
+
+	t.Run("connected on start", func(t *testing.T) {
+		restoredServer.send(t, "data: a\n\n")
+		checkTriggers(t, "restored", 1)
+		checkHash(t, "restored", "a")
+		if connections, open, _ := restoredServer.state(); connections != 1 || open != 1 {
+			t.Error(connections, open)
+		}
+	})
+
+	t.Run("deleted by another instance is closed with the resync", func(t *testing.T) {
+		err := database.Delete("restored", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+		if _, open, _ := restoredServer.state(); open != 0 {
+			t.Error(open)
+		}
+	})
+
+	t.Run("invalid config is rejected", func(t *testing.T) {
+		for _, raw := range []string{`{"endpoint":"file:///etc/passwd"}`, `{"endpoint":"http://foo","format":"xml"}`, `{"url":"http://foo"}`} {
+			err := w.Set(model.WatchedEntityInit{Id: "invalid", UserId: "user", Interval: "1h", SourceType: sse.SOURCE_TYPE, SourceConfig: json.RawMessage(raw)})
+			if err == nil {
+				t.Error("expected error", raw)
+			}
+		}
+	})
+
+	t.Run("events", func(t *testing.T) {
+		server := newSseServer()
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		set(t, "events", sse.SOURCE_TYPE, sse.Config{Endpoint: httpServer.URL})
+
+		server.send(t, "id: 1\ndata: a\n\n")
+		checkTriggers(t, "events", 1)
+		checkHash(t, "events", "a")
+
+		server.send(t, ": keep-alive\n\n")
+		server.send(t, "id: 2\ndata: a\n\n")
+		checkTriggers(t, "events", 1)
+
+		server.send(t, "id: 3\ndata: b\ndata: c\n\n")
+		checkTriggers(t, "events", 2)
+		checkHash(t, "events", "b\nc")
+
+		t.Run("reconnect", func(t *testing.T) {
+			server.mux.Lock()
+			server.reject = 2
+			server.mux.Unlock()
+			server.close <- struct{}{}
+			server.send(t, "id: 4\ndata: d\n\n")
+			checkTriggers(t, "events", 3)
+			connections, open, lastEventIds := server.state()
+			if connections != 4 || open != 1 {
+				t.Error(connections, open)
+			}
+			if lastEventIds[len(lastEventIds)-1] != "3" {
+				t.Error(lastEventIds)
+			}
+		})
+
+		t.Run("delete closes connection", func(t *testing.T) {
+			err := w.DeleteWatcher("user", "events")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+			if _, open, _ := server.state(); open != 0 {
+				t.Error(open)
+			}
+		})
+	})
+
+	t.Run("event type filter", func(t *testing.T) {
+		server := newSseServer()
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer w.DeleteWatcher("user", "filter") //closes the stream before the server
+		set(t, "filter", sse.SOURCE_TYPE, sse.Config{Endpoint: httpServer.URL, Event: "update"})
+		server.send(t, "data: a\n\n")
+		server.send(t, "event: other\ndata: b\n\n")
+		checkTriggers(t, "filter", 0)
+		server.send(t, "event: update\ndata: c\n\n")
+		checkTriggers(t, "filter", 1)
+		checkHash(t, "filter", "c")
+	})
+
+	t.Run("lines", func(t *testing.T) {
+		server := newSseServer()
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer w.DeleteWatcher("user", "lines") //closes the stream before the server
+		set(t, "lines", sse.SOURCE_TYPE, sse.Config{Endpoint: httpServer.URL, Format: sse.FORMAT_LINES})
+		server.send(t, `{"state":"on"}`+"\n")
+		checkTriggers(t, "lines", 1)
+		checkHash(t, "lines", `{"state":"on"}`)
+		server.send(t, "\n"+`{"state":"on"}`+"\n")
+		checkTriggers(t, "lines", 1)
+
+		err := w.PauseWatcher("user", "lines")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		server.send(t, `{"state":"off"}`+"\n")
+		checkTriggers(t, "lines", 1)
+		checkHash(t, "lines", `{"state":"on"}`)
+		err = w.ResumeWatcher("user", "lines")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		server.send(t, `{"state":"on"}`+"\n")
+		checkTriggers(t, "lines", 1)
+	})
+
+	t.Run("header is redacted", func(t *testing.T) {
+		server := newSseServer()
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer w.DeleteWatcher("user", "header") //closes the stream before the server
+		set(t, "header", sse.SOURCE_TYPE, sse.Config{Endpoint: httpServer.URL, Header: http.Header{"Authorization": {"secret"}}})
+		entity, err := w.GetWatcher("user", "header")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		config := sse.Config{}
+		err = json.Unmarshal(entity.SourceConfig, &config)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if config.Endpoint != httpServer.URL || config.Header.Get("Authorization") != watcher.RedactedValue {
+			t.Errorf("%#v", config)
+		}
+	})
+
+	t.Run("isolated client refuses local endpoints", func(t *testing.T) {
+		server := newSseServer()
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer w.DeleteWatcher("user", "isolated") //closes the stream before the server
+		set(t, "isolated", "isolated", sse.Config{Endpoint: httpServer.URL})
+		time.Sleep(200 * time.Millisecond)
+		if connections, _, _ := server.state(); connections != 0 {
+			t.Error(connections)
+		}
+	})
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/trigger"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+// statusServer answers every request with the configured status code and content after the configured delay
+type statusServer struct {
+	mux     sync.Mutex
+	code    int
+	content string
+	delay   time.Duration
+}
+
+func (this *statusServer) set(code int, content string, delay time.Duration) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.code = code
+	this.content = content
+	this.delay = delay
+}
+
+func (this *statusServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	this.mux.Lock()
+	code, content, delay := this.code, this.content, this.delay
+	this.mux.Unlock()
+	time.Sleep(delay)
+	writer.WriteHeader(code)
+	writer.Write([]byte(content))
+}
+
+func TestStatusCodes(t *testing.T) {
+	config := configuration.Config{}
+	database, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	c, err := checker.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tr := mocks.NewCountingTrigger()
+	w := watcher.New(config, database, c, tr, mocks.CleanupChecker{})
+
+	server := &statusServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	set := func(t *testing.T, id string, watch model.HttpRequest, hashStatusCode bool) {
+		watch.Method = "GET"
+		watch.Endpoint = httpServer.URL
+		err := database.Set(model.WatchedEntityInit{
+			Id:          id,
+			UserId:      "user",
+			Interval:    "1h",
+			HashType:    checker.HASH_TYPE_MD5,
+			HashOptions: model.HashOptions{HashStatusCode: hashStatusCode},
+			Watch:       watch,
+			Trigger:     model.HttpRequest{Endpoint: id},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	check := func(t *testing.T, id string, expectedStatus int, expectedChanged bool, expectedTriggers int) {
+		result, err := w.CheckNow("user", id, false)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.StatusCode != expectedStatus || result.Changed != expectedChanged || tr.Get()[id] != expectedTriggers {
+			t.Errorf("%#v %#v", result, tr.Get())
+		}
+	}
+
+	t.Run("404 is an error by default", func(t *testing.T) {
+		set(t, "default", model.HttpRequest{}, false)
+		server.set(http.StatusNotFound, "not found", 0)
+		_, err := w.CheckNow("user", "default", false)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("error contains a truncated response", func(t *testing.T) {
+		set(t, "large", model.HttpRequest{}, false)
+		server.set(http.StatusNotFound, strings.Repeat("x", 100000), 0)
+		_, err := w.CheckNow("user", "large", false)
+		if err == nil || !strings.Contains(err.Error(), "unexpected watch response: 404") || len(err.Error()) > 2048 {
+			t.Error(len(err.Error()), err)
+		}
+	})
+
+	t.Run("accepted 404 is watchable", func(t *testing.T) {
+		set(t, "accepted", model.HttpRequest{AcceptedStatusCodes: []int{http.StatusNotFound}}, false)
+		server.set(http.StatusNotFound, "not found", 0)
+		check(t, "accepted", http.StatusNotFound, true, 0)
+		check(t, "accepted", http.StatusNotFound, false, 0)
+		server.set(http.StatusOK, "found", 0)
+		check(t, "accepted", http.StatusOK, true, 1)
+		server.set(http.StatusInternalServerError, "found", 0)
+		_, err := w.CheckNow("user", "accepted", false)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("status code is not hashed by default", func(t *testing.T) {
+		set(t, "content", model.HttpRequest{AcceptedStatusCodes: []int{http.StatusNotFound}}, false)
+		server.set(http.StatusNotFound, "same", 0)
+		check(t, "content", http.StatusNotFound, true, 0)
+		server.set(http.StatusOK, "same", 0)
+		check(t, "content", http.StatusOK, false, 0)
+	})
+
+	t.Run("hash status code", func(t *testing.T) {
+		set(t, "status", model.HttpRequest{AcceptedStatusCodes: []int{http.StatusNotFound}}, true)
+		server.set(http.StatusNotFound, "same", 0)
+		check(t, "status", http.StatusNotFound, true, 0)
+		server.set(http.StatusOK, "same", 0)
+		check(t, "status", http.StatusOK, true, 1)
+		check(t, "status", http.StatusOK, false, 1)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		set(t, "timeout", model.HttpRequest{Timeout: "50ms"}, false)
+		server.set(http.StatusOK, "slow", 200*time.Millisecond)
+		_, err := w.CheckNow("user", "timeout", false)
+		if err == nil {
+			t.Error("expected timeout error")
+		}
+		set(t, "timeout", model.HttpRequest{Timeout: "2s"}, false)
+		check(t, "timeout", http.StatusOK, true, 0)
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		set(t, "invalid", model.HttpRequest{Timeout: "foo"}, false)
+		server.set(http.StatusOK, "ok", 0)
+		_, err := w.CheckNow("user", "invalid", false)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("timeout exceeds max", func(t *testing.T) {
+		err := c.Validate(model.WatchedEntityInit{Watch: model.HttpRequest{Timeout: "2m"}})
+		if err == nil {
+			t.Error("expected error")
+		}
+		err = c.Validate(model.WatchedEntityInit{Watch: model.HttpRequest{Timeout: "30s"}})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestTriggerStatusCodes(t *testing.T) {
+	tr, err := trigger.New(configuration.Config{}, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	server := &statusServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	t.Run("accepted status code", func(t *testing.T) {
+		server.set(http.StatusConflict, "already running", 0)
+		err := tr.Run("user", model.HttpRequest{Method: "POST", Endpoint: httpServer.URL})
+		if err == nil {
+			t.Error("expected error")
+		}
+		err = tr.Run("user", model.HttpRequest{Method: "POST", Endpoint: httpServer.URL, AcceptedStatusCodes: []int{http.StatusConflict}})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server.set(http.StatusOK, "slow", 200*time.Millisecond)
+		err := tr.Run("user", model.HttpRequest{Method: "POST", Endpoint: httpServer.URL, Timeout: "50ms"})
+		if err == nil {
+			t.Error("expected timeout error")
+		}
+		err = tr.Run("user", model.HttpRequest{Method: "POST", Endpoint: httpServer.URL, Timeout: "2s"})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("timeout is clamped to max", func(t *testing.T) {
+		bounded, err := trigger.New(configuration.Config{MaxRequestTimeout: "50ms"}, mocks.AuthMock{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		server.set(http.StatusOK, "slow", 200*time.Millisecond)
+		err = bounded.Run("user", model.HttpRequest{Method: "POST", Endpoint: httpServer.URL, Timeout: "2s"})
+		if err == nil {
+			t.Error("expected timeout error")
+		}
+	})
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/trigger"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestTriggerInputs(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config := configuration.Config{}
+	db, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	c, err := checker.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tr, err := trigger.New(config, mocks.AuthMock{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	w := watcher.New(config, db, c, tr, mocks.CleanupChecker{})
+
+	allInputs := map[string]string{
+		watcher.TRIGGER_INPUT_OLD_HASH:        "old",
+		watcher.TRIGGER_INPUT_NEW_HASH:        "new",
+		watcher.TRIGGER_INPUT_CHECK_TIMESTAMP: "timestamp",
+		watcher.TRIGGER_INPUT_RESPONSE:        "response",
+		watcher.TRIGGER_INPUT_ADDED_DEVICES:   "added",
+	}
+
+	//creates a watcher with its own http mock; responses are used in request order by watch and trigger requests
+	create := func(t *testing.T, id string, hashType string, responsePath string, responses []mocks.HttpMockResponse) (mux *sync.Mutex, requests *[]model.HttpRequest) {
+		mux, requests, err := mocks.SetHttpMockEntity(ctx, wg, db, model.WatchedEntityInit{
+			Id:       id,
+			HashType: hashType,
+			Watch:    model.HttpRequest{Endpoint: "/query"},
+			Trigger: model.HttpRequest{
+				Endpoint: "/start",
+				Body:     []byte(`[{"id":"foo","label":"foo","value":"bar","value_label":"bar"}]`),
+			},
+			TriggerInputs:       allInputs,
+			TriggerResponsePath: responsePath,
+		}, responses)
+		if err != nil {
+			t.Error(err)
+		}
+		return mux, requests
+	}
+
+	check := func(t *testing.T, id string) (result model.ManualCheckResult) {
+		result, err := w.CheckNow("user", id, false)
+		if err != nil {
+			t.Error(err)
+		}
+		return result
+	}
+
+	t.Run("json path", func(t *testing.T) {
+		mux, requests := create(t, "path", checker.HASH_TYPE_JSON_CANONICAL, "$.battery", []mocks.HttpMockResponse{
+			{Code: 200, Payload: []byte(`{"battery":50,"name":"sensor"}`)},
+			{Code: 200, Payload: []byte(`{"battery":15,"name":"sensor"}`)},
+			{Code: 200},
+		})
+		first := check(t, "path")
+		start := time.Now().Add(-time.Second)
+		second := check(t, "path")
+		if !second.Triggered {
+			t.Errorf("%#v", second)
+			return
+		}
+
+		mux.Lock()
+		defer mux.Unlock()
+		if len(*requests) != 3 || (*requests)[2].Endpoint != "/start" {
+			t.Errorf("%#v", *requests)
+			return
+		}
+		inputs, err := mocks.GetTriggerInputs((*requests)[2])
+		if err != nil || inputs["foo"] != "bar" || inputs["old"] != first.Hash || inputs["new"] != second.Hash || inputs["response"] != float64(15) {
+			t.Error(err, inputs)
+		}
+		if _, ok := inputs["added"]; ok {
+			t.Error("device diff of non deviceids hash type", inputs)
+		}
+		timestamp, err := time.Parse(time.RFC3339, inputs["timestamp"].(string))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if timestamp.Before(start) || timestamp.After(time.Now()) {
+			t.Error(timestamp)
+		}
+	})
+
+	t.Run("raw response", func(t *testing.T) {
+		mux, requests := create(t, "raw", checker.HASH_TYPE_MD5, "", []mocks.HttpMockResponse{
+			{Code: 200, Payload: []byte("foo")},
+			{Code: 200, Payload: []byte("bar")},
+			{Code: 200},
+		})
+		check(t, "raw")
+		check(t, "raw")
+
+		mux.Lock()
+		defer mux.Unlock()
+		if len(*requests) != 3 {
+			t.Errorf("%#v", *requests)
+			return
+		}
+		if inputs, err := mocks.GetTriggerInputs((*requests)[2]); err != nil || inputs["response"] != "bar" {
+			t.Error(err, inputs)
+		}
+	})
+
+	t.Run("json path on invalid json omits the response", func(t *testing.T) {
+		mux, requests := create(t, "invalid", checker.HASH_TYPE_MD5, "$.battery", []mocks.HttpMockResponse{
+			{Code: 200, Payload: []byte("foo")},
+			{Code: 200, Payload: []byte("<html>bar</html>")},
+			{Code: 200},
+		})
+		check(t, "invalid")
+		second := check(t, "invalid")
+		if !second.Triggered {
+			t.Errorf("%#v", second)
+			return
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		if len(*requests) != 3 {
+			t.Errorf("%#v", *requests)
+			return
+		}
+		inputs, err := mocks.GetTriggerInputs((*requests)[2])
+		if _, ok := inputs["response"]; err != nil || ok || inputs["new"] != second.Hash {
+			t.Error(err, inputs)
+		}
+	})
+
+	t.Run("render errors count as check failures", func(t *testing.T) {
+		targetUrl, _, _ := mocks.StartTestHttpMock(ctx, wg, []mocks.HttpMockResponse{
+			{Code: 200, Payload: []byte("foo")},
+			{Code: 200, Payload: []byte("bar")},
+		})
+		err := db.Set(model.WatchedEntityInit{
+			Id:            "render",
+			UserId:        "user",
+			Interval:      "1h",
+			HashType:      checker.HASH_TYPE_MD5,
+			Watch:         model.HttpRequest{Method: "GET", Endpoint: targetUrl + "/query"},
+			Trigger:       model.HttpRequest{Method: "POST", Endpoint: targetUrl + "/start", Body: []byte(`{"no":"list"}`)},
+			TriggerInputs: allInputs,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		check(t, "render")
+		_, err = w.CheckNow("user", "render", false)
+		if !errors.Is(err, watcher.ErrCheckFailed) {
+			t.Error(err)
+		}
+	})
+}
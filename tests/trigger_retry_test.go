@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/api"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/docker"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+func TestTriggerRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	freePort, err := docker.GetFreePortString()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	config := configuration.Config{
+		Database:            "memory",
+		AdvertisedUrl:       "http://localhost:" + freePort,
+		TriggerMaxAttempts:  3,
+		TriggerRetryBackoff: "1ms",
+	}
+
+	db, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	c := mocks.NewCountingChecker()
+	tr := mocks.NewCountingTrigger()
+	tr.SetError("trigger", errors.New("503 service unavailable"))
+	w := watcher.New(config, db, c, tr, mocks.CleanupChecker{})
+	err = api.Start(ctx, config, w)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(100 * time.Millisecond) //wait for the api to listen
+
+	err = db.Set(model.WatchedEntityInit{
+		Id:       "w1",
+		UserId:   "test-user",
+		Interval: "1h",
+		Watch:    model.HttpRequest{Endpoint: "watch"},
+		Trigger: model.HttpRequest{
+			Endpoint: "trigger",
+			Header:   http.Header{"Authorization": {"Bearer secret"}},
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = db.UpdateHash("w1", "test-user", "initial")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	request := func(userId string, method string, path string, result interface{}) (code int, err error) {
+		req, err := http.NewRequest(method, config.AdvertisedUrl+path, nil)
+		if err != nil {
+			return 0, err
+		}
+		token, err := mocks.AuthMock{}.GenerateUserTokenById(userId)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Authorization", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || result == nil {
+			return resp.StatusCode, nil
+		}
+		return resp.StatusCode, json.NewDecoder(resp.Body).Decode(result)
+	}
+
+	listDeadLetters := func(t *testing.T, userId string) (result []model.PendingTrigger) {
+		code, err := request(userId, http.MethodGet, "/dead-letters", &result)
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+			return nil
+		}
+		return result
+	}
+
+	t.Run("failed trigger is queued", func(t *testing.T) {
+		count, err := w.Run(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if count != 1 || tr.Get()["trigger"] != 1 {
+			t.Error(count, tr.Get())
+		}
+	})
+
+	t.Run("retry until dead letter", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			count, err := w.RunPendingTriggers(10)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if count != 1 {
+				t.Error(i, count)
+				return
+			}
+		}
+		if tr.Get()["trigger"] != 3 {
+			t.Error(tr.Get())
+		}
+		count, err := w.RunPendingTriggers(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if count != 0 {
+			t.Error(count)
+		}
+	})
+
+	deadLetterId := ""
+
+	t.Run("list dead letters", func(t *testing.T) {
+		list := listDeadLetters(t, "test-user")
+		if len(list) != 1 {
+			t.Error(list)
+			return
+		}
+		deadLetter := list[0]
+		if deadLetter.WatcherId != "w1" || deadLetter.Attempts != 3 || !deadLetter.DeadLetter || deadLetter.LastError != "503 service unavailable" || deadLetter.Trigger.Endpoint != "trigger" {
+			t.Errorf("%#v", deadLetter)
+		}
+		if deadLetter.Trigger.Header.Get("Authorization") != watcher.RedactedValue {
+			t.Error(deadLetter.Trigger.Header)
+		}
+		deadLetterId = deadLetter.Id
+	})
+
+	t.Run("dead letters are scoped by user", func(t *testing.T) {
+		list := listDeadLetters(t, "other-user")
+		if list == nil || len(list) != 0 {
+			t.Error(list)
+		}
+		code, err := request("other-user", http.MethodPost, "/dead-letters/"+deadLetterId+"/replay", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusNotFound {
+			t.Error(code)
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		tr.SetError("trigger", nil)
+		code, err := request("test-user", http.MethodPost, "/dead-letters/"+deadLetterId+"/replay", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusOK {
+			t.Error(code)
+			return
+		}
+		code, err = request("test-user", http.MethodPost, "/dead-letters/"+deadLetterId+"/replay", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusConflict {
+			t.Error(code)
+			return
+		}
+		if list := listDeadLetters(t, "test-user"); len(list) != 0 {
+			t.Error(list)
+		}
+		count, err := w.RunPendingTriggers(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if count != 1 || tr.Get()["trigger"] != 4 {
+			t.Error(count, tr.Get())
+		}
+		_, err = db.ReadPendingTrigger(deadLetterId, "test-user")
+		if err == nil {
+			t.Error("successful trigger was not removed")
+		}
+	})
+}
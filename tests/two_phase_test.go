@@ -0,0 +1,213 @@
+/*
+ * Copyright (c) 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
+)
+
+// TestTwoPhaseHashUpdate injects failures between storing the pending hash, running the trigger and promoting the hash.
+// a detected change must never be lost: either the hash is promoted after a delivered or queued trigger,
+// or the last hash stays unchanged and the next check triggers again
+func TestTwoPhaseHashUpdate(t *testing.T) {
+	config := configuration.Config{TriggerRetryBackoff: "1ms"}
+	m, err := memory.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	database := mocks.NewFailingDb(m)
+
+	err = database.Set(model.WatchedEntityInit{
+		Id:       "w1",
+		UserId:   "user",
+		Interval: "1h",
+		Watch:    model.HttpRequest{Endpoint: "watch"},
+		Trigger:  model.HttpRequest{Endpoint: "trigger"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	c := mocks.NewCountingChecker()
+	tr := mocks.NewCountingTrigger()
+	w := watcher.New(config, database, c, tr, mocks.CleanupChecker{})
+
+	injected := errors.New("injected failure")
+	triggerErr := errors.New("503 service unavailable")
+
+	//resets the last hash and makes the entity due, so that the next check detects a change
+	prepare := func(t *testing.T) {
+		err := database.UpdateHash("w1", "user", "initial")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = database.UpdateFailureState("w1", "user", model.FailureState{}, 0)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	checkHashes := func(t *testing.T, lastHash string, pendingHash string) {
+		e, err := database.Read("w1", "user")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if e.LastHash != lastHash || e.PendingHash != pendingHash {
+			t.Errorf("last=%#v pending=%#v", e.LastHash, e.PendingHash)
+		}
+	}
+
+	countPendingTriggers := func(t *testing.T) int {
+		list, err := database.FetchPendingTriggers(0)
+		if err != nil {
+			t.Error(err)
+			return 0
+		}
+		for _, trigger := range list {
+			err = database.RemovePendingTrigger(trigger.Id)
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		return len(list)
+	}
+
+	t.Run("failure before pending hash", func(t *testing.T) {
+		prepare(t)
+		database.SetError("SetPendingHash", injected)
+		defer database.SetError("SetPendingHash", nil)
+		_, err := w.Run(10)
+		if !errors.Is(err, injected) {
+			t.Error(err)
+			return
+		}
+		if tr.Get()["trigger"] != 0 {
+			t.Error(tr.Get())
+		}
+		checkHashes(t, "initial", "")
+	})
+
+	t.Run("crash after pending hash", func(t *testing.T) {
+		prepare(t)
+		//a crashed instance stored the pending hash but never ran the trigger
+		err := database.SetPendingHash("w1", "user", "changed")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		checkHashes(t, "initial", "changed")
+		_, err = w.Run(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if tr.Get()["trigger"] != 1 {
+			t.Error(tr.Get())
+		}
+		checkHashes(t, "changed", "")
+	})
+
+	t.Run("failure after trigger", func(t *testing.T) {
+		prepare(t)
+		database.SetError("PromotePendingHash", injected)
+		_, err := w.Run(10)
+		database.SetError("PromotePendingHash", nil)
+		if !errors.Is(err, injected) {
+			t.Error(err)
+			return
+		}
+		if tr.Get()["trigger"] != 2 {
+			t.Error(tr.Get())
+		}
+		checkHashes(t, "initial", "changed")
+
+		//the change is triggered again with the next check
+		err = database.UpdateFailureState("w1", "user", model.FailureState{}, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = w.Run(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if tr.Get()["trigger"] != 3 {
+			t.Error(tr.Get())
+		}
+		checkHashes(t, "changed", "")
+	})
+
+	t.Run("failed trigger is handed to outbox", func(t *testing.T) {
+		prepare(t)
+		tr.SetError("trigger", triggerErr)
+		defer tr.SetError("trigger", nil)
+		_, err := w.Run(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if count := countPendingTriggers(t); count != 1 {
+			t.Error(count)
+		}
+		checkHashes(t, "changed", "")
+	})
+
+	t.Run("failed trigger and failed outbox", func(t *testing.T) {
+		prepare(t)
+		tr.SetError("trigger", triggerErr)
+		database.SetError("PromotePendingHash", injected)
+		_, err := w.Run(10)
+		tr.SetError("trigger", nil)
+		database.SetError("PromotePendingHash", nil)
+		if !errors.Is(err, injected) || !errors.Is(err, triggerErr) {
+			t.Error(err)
+			return
+		}
+		if count := countPendingTriggers(t); count != 0 {
+			t.Error(count)
+		}
+		checkHashes(t, "initial", "changed")
+
+		err = database.UpdateFailureState("w1", "user", model.FailureState{}, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		triggerCount := tr.Get()["trigger"]
+		_, err = w.Run(10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if tr.Get()["trigger"] != triggerCount+1 {
+			t.Error(tr.Get())
+		}
+		checkHashes(t, "changed", "")
+	})
+}
@@ -23,7 +23,7 @@ import (
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/api"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
-	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/mongo"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/model"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/trigger"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/docker"
@@ -43,12 +43,6 @@ func TestWatcher(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	mongoUrl, err := docker.MongoRs(ctx, wg)
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
 	freePort, err := docker.GetFreePortString()
 	if err != nil {
 		t.Error(err)
@@ -56,18 +50,16 @@ func TestWatcher(t *testing.T) {
 	}
 
 	config := configuration.Config{
-		MongoUrl:                     mongoUrl,
-		MongoTable:                   "test",
-		MongoCollectionWatchedEntity: "test",
-		WatchInterval:                "300ms",
-		BatchSize:                    10,
-		ExternalDnsAddress:           "8.8.8.8:53",
-		AdvertisedUrl:                "http://localhost:" + freePort,
+		Database:           "memory",
+		WatchInterval:      "300ms",
+		BatchSize:          10,
+		ExternalDnsAddress: "8.8.8.8:53",
+		AdvertisedUrl:      "http://localhost:" + freePort,
 	}
 
 	a := mocks.AuthMock{}
 
-	db, err := mongo.New(config, ctx)
+	db, err := memory.New(config)
 	if err != nil {
 		t.Error(err)
 		return
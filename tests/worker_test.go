@@ -23,15 +23,15 @@ import (
 	"github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/camunda"
 	libconfig "github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/configuration"
 	"github.com/SENERGY-Platform/smart-service-module-worker-lib/pkg/smartservicerepository"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/configuration"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher"
-	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/checker"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/cleanup"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db"
-	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/mongo"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/db/memory"
+	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/source/kafka"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/watcher/trigger"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/pkg/worker"
-	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/docker"
 	"github.com/SENERGY-Platform/smart-service-module-worker-watcher/tests/mocks"
 	"os"
 	"sync"
@@ -56,20 +56,7 @@ func TestWithMocks(t *testing.T) {
 	conf.WatchInterval = "1h"
 	conf.DeviceSelectionUrl = "http://device-selection-url:8080"
 	conf.AllowGenericWatchRequests = true
-	conf.MongoUseRelSet = true
-
-	wg := &sync.WaitGroup{}
-	defer wg.Wait()
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	mongoUrl, err := docker.MongoRs(ctx, wg)
-	if err != nil {
-		t.Error(err)
-		return
-	}
-	conf.MongoUrl = mongoUrl
+	conf.Database = "memory"
 
 	infos, err := os.ReadDir(TEST_CASE_DIR)
 	if err != nil {
@@ -130,7 +117,7 @@ func runTest(t *testing.T, testCaseLocation string, config configuration.Config,
 	smartServiceRepo := mocks.NewSmartServiceRepoMock(libConf, config, []byte{})
 	libConf.SmartServiceRepositoryUrl = smartServiceRepo.Start(ctx, wg)
 
-	m, err := mongo.New(config, ctx)
+	m, err := memory.New(config)
 	if err != nil {
 		t.Error(err)
 		return
@@ -158,7 +145,11 @@ func runTest(t *testing.T, testCaseLocation string, config configuration.Config,
 
 func StartMock(ctx context.Context, wg *sync.WaitGroup, config configuration.Config, libConfig libconfig.Config, db db.Database) error {
 	handlerFactory := func(a *auth.Auth, smartServiceRepo *smartservicerepository.SmartServiceRepository) (camunda.Handler, error) {
-		c, err := checker.New(config, a)
+		sources, err := pkg.NewSources(config, a)
+		if err != nil {
+			return nil, err
+		}
+		err = sources.Register(kafka.SOURCE_TYPE, kafka.New(config, mocks.NewKafkaMock().NewReader))
 		if err != nil {
 			return nil, err
 		}
@@ -167,12 +158,16 @@ func StartMock(ctx context.Context, wg *sync.WaitGroup, config configuration.Con
 			return nil, err
 		}
 		cleanupChecker := cleanup.New(smartServiceRepo)
-		w := watcher.New(config, db, c, t, cleanupChecker)
+		w := watcher.New(config, db, sources, t, cleanupChecker)
+		err = sources.Start(ctx, wg, w)
+		if err != nil {
+			return nil, err
+		}
 		err = w.Start(ctx, wg)
 		if err != nil {
 			return nil, err
 		}
-		return worker.New(config, libConfig, a, smartServiceRepo, w)
+		return worker.New(config, libConfig, a, smartServiceRepo, w, sources)
 	}
 	return lib.Start(ctx, wg, libConfig, handlerFactory)
 }